@@ -34,7 +34,9 @@ var recordPool = sync.Pool{
 }
 
 func Builder() *Record {
-	return recordPool.Get().(*Record)
+	record := recordPool.Get().(*Record)
+	record.wasGot = true
+	return record
 }
 
 func (r *Record) Prefix(prefix string) *Record {
@@ -67,41 +69,32 @@ func (r *Record) NewLine(flag bool) *Record {
 	return r
 }
 
+// Build finishes the record to use with Record functions. The record is encoded with the actual date when it is
+// logged.
 func (r *Record) Build() *Record {
-	if r.prefix != nil {
-		r.rec = append(r.prefix, r.rec...)
-	}
-	if r.isShowDate {
-		r.rec = append(Now.Load().([]byte), r.rec...)
-	}
-	if r.isNewLine {
-		r.rec = append(r.rec, '\n')
-	}
 	r.wasPrepare = true
 	return r
 }
 
-// Prepare a record to use with Prepare functions.
+// Prepare a record to use with Prepare functions. A prepared record can be logged many times, every time with the
+// actual date.
 func (r *Record) Prepare() *Record {
-	if r.wasPrepare {
-		return r
-	}
-	if r.prefix != nil {
-		r.rec = append(r.prefix, r.rec...)
-	}
-	if r.isShowDate {
-		r.rec = append(Now.Load().([]byte), r.rec...)
-	}
-	if r.isNewLine {
-		r.rec = append(r.rec, '\n')
-	}
 	r.wasPrepare = true
 	return r
 }
 
 func (r *Record) Reset() {
 	r.rec = r.rec[:0]
+	r.prefix = nil
 	r.isShowDate = true
 	r.isNewLine = true
 	r.wasPrepare = false
 }
+
+// release resets the record and puts it back to the pool if it was got by Builder().
+func (r *Record) release() {
+	r.Reset()
+	if r.wasGot {
+		recordPool.Put(r)
+	}
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// packagePrefix is the prefix of the names of the functions of the package.
+var packagePrefix = reflect.TypeOf(Level(0)).PkgPath() + "."
+
+// callers caches the formatted callers by their program counters.
+var callers sync.Map
+
+// caller returns the "dir/file.go:line" location of the first caller outside the package and the log and log/slog
+// packages of the standard library, so the location is the same for all methods that lead to the log.
+func caller() string {
+	var pcs [16]uintptr
+	n := runtime.Callers(3, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		if !isInternalFrame(frame.Function) {
+			if location, ok := callers.Load(frame.PC); ok {
+				return location.(string)
+			}
+			location := formatCaller(frame.File, frame.Line)
+			callers.Store(frame.PC, location)
+			return location
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func isInternalFrame(function string) bool {
+	return strings.HasPrefix(function, packagePrefix) ||
+		strings.HasPrefix(function, "log.") ||
+		strings.HasPrefix(function, "log/slog.")
+}
+
+// splitCaller splits the caller into the file and the line.
+func splitCaller(caller string) (file, line string) {
+	if i := strings.LastIndexByte(caller, ':'); i >= 0 {
+		return caller[:i], caller[i+1:]
+	}
+	return caller, ""
+}
+
+// formatCaller returns the file with its directory and the line, like "server/handler.go:42".
+func formatCaller(file string, line int) string {
+	if i := strings.LastIndexByte(file, '/'); i >= 0 {
+		if j := strings.LastIndexByte(file[:i], '/'); j >= 0 {
+			file = file[j+1:]
+		}
+	}
+	return file + ":" + strconv.Itoa(line)
+}
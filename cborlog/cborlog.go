@@ -0,0 +1,386 @@
+// Package cborlog reads the logs written by logger.CBOREncoder and converts them back into text or JSON.
+//
+// Example:
+//
+//	decoder := cborlog.NewDecoder(file)
+//	for {
+//		entry, err := decoder.Decode()
+//		if err == io.EOF {
+//			break
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		os.Stdout.Write(entry.AppendTo(nil, logger.NewJSONEncoder()))
+//	}
+package cborlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/Eugene-Usachev/logger"
+	"io"
+	"math"
+	"time"
+)
+
+// maxLength is the maximum length of a string or a collection. Longer data items are treated as corrupted.
+const maxLength = 1 << 28
+
+// errBreak is returned by readItem when it reads the "break" stop code of an indefinite-length item.
+var errBreak = errors.New("cborlog: unexpected break")
+
+// Entry is a decoded log.
+type Entry struct {
+	// Time is the time of the log. It is zero if the log has no date.
+	Time  time.Time
+	Level logger.Level
+	// Caller is the location of the code that logged. It is empty if the logger did not show callers.
+	Caller string
+	// Message is the message of the log, including the prefix of a record.
+	Message string
+	// Fields are the context fields and the fields of the log in the order they were written.
+	Fields []Field
+}
+
+// Field is a decoded field. The Value is a string, an int64, a uint64 (for integers larger than math.MaxInt64),
+// a float64, a bool, a time.Time, nil, a []byte, a []interface{} or a map[string]interface{}. Durations are float64
+// numbers of milliseconds.
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// AppendTo appends the entry, encoded by the encoder, to dst. The date is written in the date format of the logger.
+func (entry *Entry) AppendTo(dst []byte, enc logger.Encoder) []byte {
+	var fields []byte
+	for _, field := range entry.Fields {
+		switch v := field.Value.(type) {
+		case string:
+			fields = enc.AppendString(fields, field.Key, v)
+		case int64:
+			fields = enc.AppendInt(fields, field.Key, v)
+		case uint64:
+			fields = enc.AppendUint(fields, field.Key, v)
+		case float64:
+			fields = enc.AppendFloat(fields, field.Key, v)
+		case bool:
+			fields = enc.AppendBool(fields, field.Key, v)
+		case time.Time:
+			fields = enc.AppendTime(fields, field.Key, v)
+		default:
+			fields = enc.AppendAny(fields, field.Key, v)
+		}
+	}
+	e := logger.Entry{
+		Level:   entry.Level,
+		Caller:  entry.Caller,
+		Message: []byte(entry.Message),
+		Fields:  fields,
+	}
+	if !entry.Time.IsZero() {
+		e.Time = entry.Time
+		e.Date = entry.Time.AppendFormat(nil, logger.TimeFormatDefault)
+	}
+	return enc.AppendEntry(dst, e)
+}
+
+// Decoder reads entries from a CBOR sequence.
+type Decoder struct {
+	r *bufio.Reader
+}
+
+// NewDecoder creates a new Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: bufio.NewReader(r)}
+}
+
+// Decode reads the next entry. It returns io.EOF when there are no more entries and io.ErrUnexpectedEOF if the last
+// entry is truncated.
+func (d *Decoder) Decode() (*Entry, error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if b>>5 != 5 {
+		return nil, fmt.Errorf("cborlog: an entry must be a map, got the initial byte %#x", b)
+	}
+	n, indefinite, err := d.readArgument(b & 31)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	entry := &Entry{}
+	for i := uint64(0); indefinite || i < n; i++ {
+		key, err := d.readItem()
+		if err == errBreak && indefinite {
+			break
+		}
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		value, err := d.readItem()
+		if err != nil {
+			return nil, unexpectedEOF(err)
+		}
+		name, ok := key.(string)
+		if !ok {
+			name = fmt.Sprint(key)
+		}
+		if err = entry.set(name, value); err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+func (entry *Entry) set(key string, value interface{}) error {
+	switch key {
+	case "time":
+		t, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("cborlog: the time must be a tagged time, got %T", value)
+		}
+		entry.Time = t
+	case "level":
+		level, ok := value.(int64)
+		if !ok {
+			return fmt.Errorf("cborlog: the level must be an integer, got %T", value)
+		}
+		entry.Level = logger.Level(level)
+	case "caller":
+		entry.Caller = fmt.Sprint(value)
+	case "msg":
+		entry.Message = fmt.Sprint(value)
+	default:
+		entry.Fields = append(entry.Fields, Field{Key: key, Value: value})
+	}
+	return nil
+}
+
+// readArgument reads the argument of a data item with the additional information info of its initial byte.
+func (d *Decoder) readArgument(info byte) (n uint64, indefinite bool, err error) {
+	switch {
+	case info < 24:
+		return uint64(info), false, nil
+	case info <= 27:
+		var buf [8]byte
+		size := 1 << (info - 24)
+		if _, err = io.ReadFull(d.r, buf[8-size:]); err != nil {
+			return 0, false, err
+		}
+		return binary.BigEndian.Uint64(buf[:]), false, nil
+	case info == 31:
+		return 0, true, nil
+	default:
+		return 0, false, fmt.Errorf("cborlog: invalid additional information %d", info)
+	}
+}
+
+// readItem reads a data item.
+func (d *Decoder) readItem() (interface{}, error) {
+	b, err := d.r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	major, info := b>>5, b&31
+	if major == 7 {
+		return d.readSimple(info)
+	}
+	n, indefinite, err := d.readArgument(info)
+	if err != nil {
+		return nil, err
+	}
+	if indefinite && (major == 0 || major == 1 || major == 6) {
+		return nil, fmt.Errorf("cborlog: major type %d can't have an indefinite length", major)
+	}
+	switch major {
+	case 0:
+		if n > math.MaxInt64 {
+			return n, nil
+		}
+		return int64(n), nil
+	case 1:
+		if n > math.MaxInt64 {
+			return nil, errors.New("cborlog: a negative integer is out of the int64 range")
+		}
+		return -1 - int64(n), nil
+	case 2, 3:
+		data, err := d.readString(major, n, indefinite)
+		if err != nil {
+			return nil, err
+		}
+		if major == 3 {
+			return string(data), nil
+		}
+		return data, nil
+	case 4:
+		var array []interface{}
+		for i := uint64(0); indefinite || i < n; i++ {
+			item, err := d.readItem()
+			if err == errBreak && indefinite {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, item)
+		}
+		return array, nil
+	case 5:
+		m := make(map[string]interface{})
+		for i := uint64(0); indefinite || i < n; i++ {
+			key, err := d.readItem()
+			if err == errBreak && indefinite {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			value, err := d.readItem()
+			if err != nil {
+				return nil, err
+			}
+			m[fmt.Sprint(key)] = value
+		}
+		return m, nil
+	default:
+		return d.readTagged(n)
+	}
+}
+
+// readString reads the bytes of a byte string or a text string. An indefinite-length string is a sequence of
+// definite-length strings of the same major type.
+func (d *Decoder) readString(major byte, n uint64, indefinite bool) ([]byte, error) {
+	if !indefinite {
+		if n > maxLength {
+			return nil, fmt.Errorf("cborlog: a string of %d bytes is too long", n)
+		}
+		data := make([]byte, n)
+		_, err := io.ReadFull(d.r, data)
+		return data, err
+	}
+	var data []byte
+	for {
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == 0xff {
+			return data, nil
+		}
+		if b>>5 != major {
+			return nil, fmt.Errorf("cborlog: a chunk of an indefinite-length string has the major type %d", b>>5)
+		}
+		n, indefinite, err := d.readArgument(b & 31)
+		if err != nil {
+			return nil, err
+		}
+		if indefinite {
+			return nil, errors.New("cborlog: a chunk of an indefinite-length string is indefinite")
+		}
+		chunk, err := d.readString(major, n, false)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk...)
+	}
+}
+
+// readTagged reads the data item of the tag. Epoch times (tag 1) and RFC 3339 times (tag 0) are decoded as time.Time,
+// the data items of other tags are returned as is.
+func (d *Decoder) readTagged(tag uint64) (interface{}, error) {
+	item, err := d.readItem()
+	if err != nil {
+		return nil, err
+	}
+	switch tag {
+	case 0:
+		s, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("cborlog: a time with the tag 0 must be a string, got %T", item)
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case 1:
+		switch v := item.(type) {
+		case int64:
+			return time.Unix(v, 0), nil
+		case uint64:
+			return time.Unix(int64(v), 0), nil
+		case float64:
+			sec, frac := math.Modf(v)
+			return time.Unix(int64(sec), int64(frac*float64(time.Second))), nil
+		default:
+			return nil, fmt.Errorf("cborlog: a time with the tag 1 must be a number, got %T", item)
+		}
+	default:
+		return item, nil
+	}
+}
+
+// readSimple reads a simple value or a float with the additional information info.
+func (d *Decoder) readSimple(info byte) (interface{}, error) {
+	switch info {
+	case 20:
+		return false, nil
+	case 21:
+		return true, nil
+	case 22, 23:
+		return nil, nil
+	case 25, 26, 27:
+		bits, _, err := d.readArgument(info)
+		if err != nil {
+			return nil, err
+		}
+		switch info {
+		case 25:
+			return halfToFloat(uint16(bits)), nil
+		case 26:
+			return float64(math.Float32frombits(uint32(bits))), nil
+		default:
+			return math.Float64frombits(bits), nil
+		}
+	case 28, 29, 30:
+		return nil, fmt.Errorf("cborlog: invalid additional information %d", info)
+	case 31:
+		return nil, errBreak
+	default:
+		if info == 24 {
+			b, err := d.r.ReadByte()
+			return int64(b), err
+		}
+		return int64(info), nil
+	}
+}
+
+// halfToFloat converts an IEEE 754 half-precision float to a float64.
+func halfToFloat(half uint16) float64 {
+	exp := int(half>>10) & 0x1f
+	mant := float64(half & 0x3ff)
+	var f float64
+	switch exp {
+	case 0:
+		f = math.Ldexp(mant, -24)
+	case 31:
+		if mant == 0 {
+			f = math.Inf(1)
+		} else {
+			f = math.NaN()
+		}
+	default:
+		f = math.Ldexp(mant+1024, exp-25)
+	}
+	if half&0x8000 != 0 {
+		return -f
+	}
+	return f
+}
+
+// unexpectedEOF converts io.EOF in the middle of an entry to io.ErrUnexpectedEOF.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,135 @@
+package cborlog_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Eugene-Usachev/logger"
+	"github.com/Eugene-Usachev/logger/cborlog"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newCBORLogger(buf *bytes.Buffer) *logger.StandardLogger {
+	return logger.NewStandardLogger(&logger.StandardLoggerConfig{
+		InfoWriter:   buf,
+		ErrorWriter:  buf,
+		RecordWriter: buf,
+		ShowDate:     true,
+		PreciseTime:  true,
+		Encoder:      logger.NewCBOREncoder(),
+	})
+}
+
+func decodeAll(t *testing.T, data []byte) []*cborlog.Entry {
+	t.Helper()
+	decoder := cborlog.NewDecoder(bytes.NewReader(data))
+	var entries []*cborlog.Entry
+	for {
+		entry, err := decoder.Decode()
+		if err == io.EOF {
+			return entries
+		}
+		if err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		entries = append(entries, entry)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	log := newCBORLogger(&buf)
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	before := time.Now()
+	log.With("service", "billing").InfoEvent().
+		Int("negative", -42).
+		Int64("min", math.MinInt64).
+		Uint64("max", math.MaxUint64).
+		Float64("ratio", 0.25).
+		Bool("ok", true).
+		Dur("took", 1500*time.Microsecond).
+		Time("at", at).
+		Msg("order created")
+	log.ErrorKV("payment failed", "reason", nil, "error", errors.New("card declined"))
+	log.Record(logger.Builder().Prefix("[orders] ").AppendArgs("record ", 7).Build())
+
+	entries := decodeAll(t, buf.Bytes())
+	if len(entries) != 3 {
+		t.Fatalf("decoded %d entries, want 3", len(entries))
+	}
+
+	info := entries[0]
+	if info.Level != logger.LevelInfo || info.Message != "order created" {
+		t.Errorf("entry = %v %q, want info \"order created\"", info.Level, info.Message)
+	}
+	if info.Time.Before(before.Add(-time.Microsecond)) || info.Time.After(time.Now()) {
+		t.Errorf("Time = %v, want the time of the call", info.Time)
+	}
+	wantFields := []cborlog.Field{
+		{Key: "service", Value: "billing"},
+		{Key: "negative", Value: int64(-42)},
+		{Key: "min", Value: int64(math.MinInt64)},
+		{Key: "max", Value: uint64(math.MaxUint64)},
+		{Key: "ratio", Value: 0.25},
+		{Key: "ok", Value: true},
+		{Key: "took", Value: 1.5},
+		{Key: "at", Value: at},
+	}
+	if len(info.Fields) != len(wantFields) {
+		t.Fatalf("Fields = %v, want %v", info.Fields, wantFields)
+	}
+	for i, want := range wantFields {
+		got := info.Fields[i]
+		if wantTime, ok := want.Value.(time.Time); ok {
+			gotTime, ok := got.Value.(time.Time)
+			if got.Key != want.Key || !ok || !gotTime.Equal(wantTime) {
+				t.Errorf("Fields[%d] = %v, want %v", i, got, want)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Fields[%d] = %#v, want %#v", i, got, want)
+		}
+	}
+
+	failed := entries[1]
+	wantFields = []cborlog.Field{{Key: "reason", Value: nil}, {Key: "error", Value: "card declined"}}
+	if failed.Level != logger.LevelError || !reflect.DeepEqual(failed.Fields, wantFields) {
+		t.Errorf("entry = %v %#v, want error %#v", failed.Level, failed.Fields, wantFields)
+	}
+
+	record := entries[2]
+	if record.Message != "[orders] record 7" || record.Fields != nil {
+		t.Errorf("record = %q %v, want \"[orders] record 7\" without fields", record.Message, record.Fields)
+	}
+}
+
+func TestAppendTo(t *testing.T) {
+	var buf bytes.Buffer
+	newCBORLogger(&buf).InfoKV("order created", "id", 42, "user", "bob")
+	entries := decodeAll(t, buf.Bytes())
+	if len(entries) != 1 {
+		t.Fatalf("decoded %d entries, want 1", len(entries))
+	}
+	entries[0].Time = time.Time{}
+	got := string(entries[0].AppendTo(nil, logger.NewJSONEncoder()))
+	want := `{"level":"info","msg":"order created","id":42,"user":"bob"}` + "\n"
+	if got != want {
+		t.Errorf("AppendTo() = %q, want %q", got, want)
+	}
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	var buf bytes.Buffer
+	newCBORLogger(&buf).InfoKV("order created", "id", 42)
+	data := buf.Bytes()
+	for n := 1; n < len(data); n++ {
+		_, err := cborlog.NewDecoder(bytes.NewReader(data[:n])).Decode()
+		if err != io.ErrUnexpectedEOF {
+			t.Fatalf("Decode() of %d of %d bytes error = %v, want io.ErrUnexpectedEOF", n, len(data), err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Command cborlog converts logs written by logger.CBOREncoder into text or JSON lines.
+//
+// Usage:
+//
+//	cborlog [-format text|json] [file ...]
+//
+// It reads the standard input if no files are given.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"github.com/Eugene-Usachev/logger"
+	"github.com/Eugene-Usachev/logger/cborlog"
+	"io"
+	"os"
+)
+
+func main() {
+	format := flag.String("format", "text", "the output format: text or json")
+	flag.Parse()
+
+	var enc logger.Encoder
+	switch *format {
+	case "text":
+		enc = logger.NewTemplateEncoder("{time} {level} {caller} {msg}")
+	case "json":
+		enc = logger.NewJSONEncoder()
+	default:
+		fmt.Fprintf(os.Stderr, "cborlog: unknown format %q\n", *format)
+		os.Exit(2)
+	}
+
+	out := bufio.NewWriter(os.Stdout)
+	defer out.Flush()
+
+	if flag.NArg() == 0 {
+		if err := convert(out, os.Stdin, enc); err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "cborlog: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	for _, name := range flag.Args() {
+		file, err := os.Open(name)
+		if err == nil {
+			err = convert(out, file, enc)
+			file.Close()
+		}
+		if err != nil {
+			out.Flush()
+			fmt.Fprintf(os.Stderr, "cborlog: %s: %v\n", name, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// convert writes the entries read from r to out, encoded by the encoder.
+func convert(out *bufio.Writer, r io.Reader, enc logger.Encoder) error {
+	decoder := cborlog.NewDecoder(r)
+	var buf []byte
+	for {
+		entry, err := decoder.Decode()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		buf = entry.AppendTo(buf[:0], enc)
+		if _, err = out.Write(buf); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package logger
+
+import (
+	"github.com/mattn/go-isatty"
+	"os"
+)
+
+// colorReset resets the color of the console.
+const colorReset = "\x1b[0m"
+
+// levelColor returns the ANSI escape code of the color of the level in the console.
+func levelColor(level Level) string {
+	switch level {
+	case LevelTrace:
+		return "\x1b[90m"
+	case LevelDebug:
+		return "\x1b[36m"
+	case LevelInfo:
+		return "\x1b[34m"
+	case LevelSuccess:
+		return "\x1b[32m"
+	case LevelWarning:
+		return "\x1b[33m"
+	case LevelError:
+		return "\x1b[31m"
+	case LevelFatal:
+		return "\x1b[1;31m"
+	case levelRecord:
+		return "\x1b[35m"
+	default:
+		return ""
+	}
+}
+
+// isColorTerminal reports whether the file is a terminal that should be colored. A non-empty NO_COLOR environment
+// variable disables the colors. See https://no-color.org.
+func isColorTerminal(file *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	fd := file.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// appendColored appends the lines of the buf in the color of the level to dst. Every line is colored separately,
+// so the color does not leak into the next line or the prompt.
+func appendColored(dst []byte, level Level, buf []byte) []byte {
+	color := levelColor(level)
+	if color == "" {
+		return append(dst, buf...)
+	}
+	for len(buf) > 0 {
+		line := buf
+		next := len(buf)
+		for i := 0; i < len(buf); i++ {
+			if buf[i] == '\n' {
+				line = buf[:i]
+				next = i + 1
+				break
+			}
+		}
+		dst = append(dst, color...)
+		dst = append(dst, line...)
+		dst = append(dst, colorReset...)
+		if next > len(line) {
+			dst = append(dst, '\n')
+		}
+		buf = buf[next:]
+	}
+	return dst
+}
+
+// coloredSize returns the capacity of a buffer for the colored buf.
+func coloredSize(buf []byte) int {
+	return len(buf) + 16*(1+len(buf)/64)
+}
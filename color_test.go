@@ -0,0 +1,43 @@
+package logger
+
+import (
+	"os"
+	"testing"
+)
+
+func TestAppendColored(t *testing.T) {
+	tests := []struct {
+		level Level
+		buf   string
+		want  string
+	}{
+		{LevelInfo, "info\n", "\x1b[34minfo\x1b[0m\n"},
+		{LevelError, "no newline", "\x1b[31mno newline\x1b[0m"},
+		{LevelWarning, "first\nsecond\n", "\x1b[33mfirst\x1b[0m\n\x1b[33msecond\x1b[0m\n"},
+		{LevelSuccess, "\n", "\x1b[32m\x1b[0m\n"},
+		{levelRecord, "record\n", "\x1b[35mrecord\x1b[0m\n"},
+		{Level(42), "unknown\n", "unknown\n"},
+	}
+	for _, test := range tests {
+		got := appendColored(nil, test.level, []byte(test.buf))
+		if string(got) != test.want {
+			t.Errorf("appendColored(%v, %q) = %q, want %q", test.level, test.buf, got, test.want)
+		}
+	}
+}
+
+func TestIsColorTerminal(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "console")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if isColorTerminal(file) {
+		t.Error("isColorTerminal() of a regular file = true, want false")
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if isColorTerminal(os.Stderr) {
+		t.Error("isColorTerminal() with NO_COLOR = true, want false")
+	}
+}
@@ -0,0 +1,72 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// loggerContextKey is the key of the logger in a context.
+type loggerContextKey struct{}
+
+// NewContext returns a copy of the ctx that carries the logger. Store a child logger, created with With, to make
+// request-scoped fields available to everything that handles the request.
+func NewContext(ctx context.Context, logger Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, logger)
+}
+
+// FromContext returns the logger stored in the ctx by NewContext. It returns a NopLogger if the ctx has no logger.
+func FromContext(ctx context.Context) Logger {
+	if logger, ok := ctx.Value(loggerContextKey{}).(Logger); ok {
+		return logger
+	}
+	return NopLogger{}
+}
+
+// contextField is a registered context key and the name of its field.
+type contextField struct {
+	key  any
+	name string
+}
+
+var (
+	// contextFields is the *[]contextField of the registered context keys. It is replaced on every registration,
+	// so it can be read without locking.
+	contextFields      atomic.Pointer[[]contextField]
+	contextFieldsMutex sync.Mutex
+)
+
+// RegisterContextKey registers the context key. The Ctx methods, like InfoCtx, write the value of the key in the
+// context as the field with the name. Register the keys at startup, before logging.
+//
+// Example:
+//
+//	logger.RegisterContextKey(requestIDKey{}, "request_id")
+//	fastLogger.InfoCtx(ctx, "order created") // 2023/10/01 12:00:00 order created request_id=42
+func RegisterContextKey(key any, name string) {
+	contextFieldsMutex.Lock()
+	defer contextFieldsMutex.Unlock()
+	var fields []contextField
+	if old := contextFields.Load(); old != nil {
+		fields = append(fields, *old...)
+	}
+	fields = append(fields, contextField{key: key, name: name})
+	contextFields.Store(&fields)
+}
+
+// appendContextFields appends the values of the registered context keys in the ctx to dst as fields, encoded by the
+// encoder.
+func appendContextFields(enc Encoder, dst []byte, ctx context.Context) []byte {
+	fields := contextFields.Load()
+	if fields == nil || ctx == nil {
+		return dst
+	}
+	for _, field := range *fields {
+		value := ctx.Value(field.key)
+		if value == nil {
+			continue
+		}
+		dst = appendField(enc, dst, field.name, value)
+	}
+	return dst
+}
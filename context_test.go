@@ -0,0 +1,60 @@
+package logger_test
+
+import (
+	"context"
+	"github.com/Eugene-Usachev/logger"
+	"testing"
+	"time"
+)
+
+type requestIDKey struct{}
+
+type tenantKey struct{}
+
+func init() {
+	logger.RegisterContextKey(requestIDKey{}, "request_id")
+	logger.RegisterContextKey(tenantKey{}, "tenant")
+}
+
+func TestFromContext(t *testing.T) {
+	if got := logger.FromContext(context.Background()); got != (logger.NopLogger{}) {
+		t.Errorf("FromContext() of a context without a logger = %#v, want NopLogger{}", got)
+	}
+	log := logger.NewStandardLogger(&logger.StandardLoggerConfig{})
+	if got := logger.FromContext(logger.NewContext(context.Background(), log)); got != log {
+		t.Errorf("FromContext() = %p, want the logger of NewContext %p", got, log)
+	}
+}
+
+func TestCtxFields(t *testing.T) {
+	tests := []struct {
+		ctx  context.Context
+		want string
+	}{
+		{context.Background(), "order created\n"},
+		{context.WithValue(context.Background(), requestIDKey{}, 42), "order created request_id=42\n"},
+		{
+			context.WithValue(context.WithValue(context.Background(), tenantKey{}, "acme corp"), requestIDKey{}, "r-1"),
+			`order created request_id=r-1 tenant="acme corp"` + "\n",
+		},
+	}
+	for _, test := range tests {
+		var w levelWriters
+		cfg := w.config()
+		logger.NewStandardLogger(&cfg).InfoCtx(test.ctx, "order created")
+		if w.info.String() != test.want {
+			t.Errorf("InfoCtx() = %q, want %q", w.info.String(), test.want)
+		}
+	}
+}
+
+func TestCtxWithChildLogger(t *testing.T) {
+	var w levelWriters
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: w.config(), FlushInterval: time.Hour})
+	defer fastLogger.Stop()
+	ctx := context.WithValue(context.Background(), requestIDKey{}, 42)
+	ctx = logger.NewContext(ctx, fastLogger.With("user", "bob"))
+	logger.FromContext(ctx).ErrorCtx(ctx, "payment failed")
+	fastLogger.Flush()
+	checkLevelWriters(t, &w, map[string]string{"error": "user=bob payment failed request_id=42\n"})
+}
@@ -0,0 +1,293 @@
+package logger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// The columns of a CSVEncoder with the parts of the entries. Other columns are fields.
+const (
+	CSVColumnTime    = "time"
+	CSVColumnLevel   = "level"
+	CSVColumnCaller  = "caller"
+	CSVColumnPrefix  = "prefix"
+	CSVColumnMessage = "message"
+	// CSVColumnFields is the column with the fields that have no columns, as "key=value" pairs.
+	CSVColumnFields = "fields"
+)
+
+// CSVEncoderConfig is the configuration of a CSVEncoder.
+type CSVEncoderConfig struct {
+	// Columns are the columns of the rows. A column is a part of the entry, like CSVColumnTime or CSVColumnMessage,
+	// or the key of a field. By default, they are time, level, prefix, message and fields.
+	Columns []string
+	// Comma is the separator of the cells. By default, it's ','. Use '\t' for TSV.
+	Comma byte
+	// Header indicates whether the header row is written before the first row of every writer of the logger.
+	// The header is written only to a writer that is empty, like a new file, so a file reopened for appending keeps
+	// one header. Other writers than files are considered empty.
+	Header bool
+}
+
+// CSVEncoder encodes entries into CSV rows with fixed columns, quoting the cells like RFC 4180:
+//
+//	2023/10/01 12:00:00,info,,order created,42,"note=""quoted, with a comma"""
+//
+// A cell is quoted if it contains the separator, a quote, a newline or a carriage return; quotes in quoted cells are
+// doubled. A field is written into the column of its key, and the fields without columns are written into the fields
+// column, if any. A column of a field that the entry doesn't have is empty. Durations are written as numbers of
+// milliseconds and times in RFC 3339 format.
+type CSVEncoder struct {
+	columns []string
+	comma   byte
+	header  []byte
+}
+
+// NewCSVEncoder creates a new CSVEncoder.
+func NewCSVEncoder(cfg *CSVEncoderConfig) *CSVEncoder {
+	enc := &CSVEncoder{
+		columns: cfg.Columns,
+		comma:   cfg.Comma,
+	}
+	if len(enc.columns) == 0 {
+		enc.columns = []string{CSVColumnTime, CSVColumnLevel, CSVColumnPrefix, CSVColumnMessage, CSVColumnFields}
+	}
+	if enc.comma == 0 {
+		enc.comma = ','
+	}
+	if cfg.Header {
+		for i, column := range enc.columns {
+			if i > 0 {
+				enc.header = append(enc.header, enc.comma)
+			}
+			start := len(enc.header)
+			enc.header = append(enc.header, column...)
+			enc.header = enc.quoteCell(enc.header, start)
+		}
+		enc.header = append(enc.header, '\n')
+	}
+	return enc
+}
+
+// headerRow returns the header row, or nil if the encoder has no header.
+func (enc *CSVEncoder) headerRow() []byte {
+	return enc.header
+}
+
+// headerEncoder is an encoder with a header that is written before the first log of every writer.
+type headerEncoder interface {
+	headerRow() []byte
+}
+
+// headerWriter writes the header before the first write if the writer is empty.
+type headerWriter struct {
+	writer io.Writer
+	header []byte
+	once   sync.Once
+}
+
+func (w *headerWriter) Write(p []byte) (int, error) {
+	var err error
+	w.once.Do(func() {
+		if isEmptyWriter(w.writer) {
+			_, err = w.writer.Write(w.header)
+		}
+	})
+	if err != nil {
+		return 0, err
+	}
+	return w.writer.Write(p)
+}
+
+// isEmptyWriter reports whether nothing was written to the writer before. Only a regular file that is not empty
+// is not empty, as the contents of other writers are unknown.
+func isEmptyWriter(writer io.Writer) bool {
+	file, ok := writer.(*os.File)
+	if !ok {
+		return true
+	}
+	info, err := file.Stat()
+	return err != nil || !info.Mode().IsRegular() || info.Size() == 0
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *CSVEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	for i, column := range enc.columns {
+		if i > 0 {
+			dst = append(dst, enc.comma)
+		}
+		start := len(dst)
+		switch column {
+		case CSVColumnTime:
+			dst = append(dst, entry.Date...)
+		case CSVColumnLevel:
+			if entry.Label != nil {
+				dst = append(dst, entry.Label...)
+			} else {
+				dst = append(dst, entry.Level.String()...)
+			}
+		case CSVColumnCaller:
+			dst = append(dst, entry.Caller...)
+		case CSVColumnPrefix:
+			dst = append(dst, entry.Prefix...)
+		case CSVColumnMessage:
+			dst = append(dst, entry.Message...)
+		case CSVColumnFields:
+			dst = enc.appendOtherFields(dst, start, entry.Context)
+			dst = enc.appendOtherFields(dst, start, entry.Fields)
+		default:
+			value, ok := csvFieldValue(entry.Fields, column)
+			if !ok {
+				value, _ = csvFieldValue(entry.Context, column)
+			}
+			dst = append(dst, value...)
+		}
+		dst = enc.quoteCell(dst, start)
+	}
+	return append(dst, '\n')
+}
+
+// The fields are encoded as the keys and the values with their lengths as 4-byte big-endian integers, so that
+// AppendEntry can put them into their columns.
+
+// nextCSVField returns the key and the value of the first field of the fields and the rest of the fields.
+func nextCSVField(fields []byte) (key, value, rest []byte) {
+	n := binary.BigEndian.Uint32(fields)
+	key, fields = fields[4:4+n], fields[4+n:]
+	n = binary.BigEndian.Uint32(fields)
+	return key, fields[4 : 4+n], fields[4+n:]
+}
+
+// csvFieldValue returns the value of the last field with the key.
+func csvFieldValue(fields []byte, key string) (value []byte, ok bool) {
+	for len(fields) > 0 {
+		var k, v []byte
+		k, v, fields = nextCSVField(fields)
+		if fastbytes.B2S(k) == key {
+			value, ok = v, true
+		}
+	}
+	return value, ok
+}
+
+// appendOtherFields appends the fields without columns to the cell that starts at start as "key=value" pairs
+// separated with spaces.
+func (enc *CSVEncoder) appendOtherFields(dst []byte, start int, fields []byte) []byte {
+	for len(fields) > 0 {
+		var key, value []byte
+		key, value, fields = nextCSVField(fields)
+		if enc.hasColumn(fastbytes.B2S(key)) {
+			continue
+		}
+		if len(dst) > start {
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, key...)
+		dst = append(dst, '=')
+		dst = appendString(dst, fastbytes.B2S(value))
+	}
+	return dst
+}
+
+func (enc *CSVEncoder) hasColumn(key string) bool {
+	for _, column := range enc.columns {
+		if column == key {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteCell quotes the cell dst[start:] if it contains the separator, a quote, a newline or a carriage return.
+func (enc *CSVEncoder) quoteCell(dst []byte, start int) []byte {
+	quotes, isQuoted := 0, false
+	for _, c := range dst[start:] {
+		switch c {
+		case '"':
+			quotes++
+			isQuoted = true
+		case enc.comma, '\n', '\r':
+			isQuoted = true
+		}
+	}
+	if !isQuoted {
+		return dst
+	}
+	end := len(dst)
+	for i := 0; i < quotes+2; i++ {
+		dst = append(dst, 0)
+	}
+	// move the cell from its end, doubling the quotes
+	j := len(dst) - 1
+	dst[j] = '"'
+	j--
+	for i := end - 1; i >= start; i-- {
+		dst[j] = dst[i]
+		j--
+		if dst[i] == '"' {
+			dst[j] = '"'
+			j--
+		}
+	}
+	dst[j] = '"'
+	return dst
+}
+
+// appendField appends the key and the value appended by appendValue with their lengths.
+func (enc *CSVEncoder) appendField(dst []byte, key string, value []byte) []byte {
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(key)))
+	dst = append(dst, key...)
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(value)))
+	return append(dst, value...)
+}
+
+func (enc *CSVEncoder) AppendString(dst []byte, key, value string) []byte {
+	return enc.appendField(dst, key, fastbytes.S2B(value))
+}
+
+func (enc *CSVEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	var buf [20]byte
+	return enc.appendField(dst, key, strconv.AppendInt(buf[:0], value, 10))
+}
+
+func (enc *CSVEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	var buf [20]byte
+	return enc.appendField(dst, key, strconv.AppendUint(buf[:0], value, 10))
+}
+
+func (enc *CSVEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	var buf [32]byte
+	return enc.appendField(dst, key, strconv.AppendFloat(buf[:0], value, 'f', -1, 64))
+}
+
+func (enc *CSVEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	var buf [5]byte
+	return enc.appendField(dst, key, strconv.AppendBool(buf[:0], value))
+}
+
+// AppendDuration appends the duration as a number of milliseconds.
+func (enc *CSVEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	var buf [32]byte
+	return enc.appendField(dst, key, strconv.AppendFloat(buf[:0], float64(value)/float64(time.Millisecond), 'f', -1, 64))
+}
+
+// AppendTime appends the time in RFC 3339 format.
+func (enc *CSVEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	var buf [64]byte
+	return enc.appendField(dst, key, appendTime(buf[:0], value))
+}
+
+func (enc *CSVEncoder) AppendError(dst []byte, key string, err error) []byte {
+	return enc.AppendString(dst, key, err.Error())
+}
+
+// AppendAny appends the value formatted by fmt.
+func (enc *CSVEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	return enc.AppendString(dst, key, fmt.Sprint(value))
+}
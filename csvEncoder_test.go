@@ -0,0 +1,127 @@
+package logger_test
+
+import (
+	"bytes"
+	"github.com/Eugene-Usachev/logger"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCSVEncoderEntry(t *testing.T) {
+	columns := []string{"time", "level", "prefix", "message", "order_id", "fields"}
+	enc := logger.NewCSVEncoder(&logger.CSVEncoderConfig{Columns: columns})
+	tests := []struct {
+		entry logger.Entry
+		want  string
+	}{
+		{
+			logger.Entry{Date: []byte("2023/10/01 12:00:00"), Level: logger.LevelInfo, Message: []byte("order created"),
+				Fields: enc.AppendDuration(enc.AppendInt(enc.AppendString(nil, "user", "bob"), "order_id", 42),
+					"took", 12500*time.Microsecond)},
+			"2023/10/01 12:00:00,info,,order created,42,user=bob took=12.5",
+		},
+		{
+			logger.Entry{Level: logger.LevelError, Label: []byte("ERR"), Message: []byte(`say "hi", then go`)},
+			`,ERR,,"say ""hi"", then go",,`,
+		},
+		{
+			logger.Entry{Level: -1, Prefix: []byte("[db] "), Message: []byte("line one\r\nline two")},
+			",record,[db] ,\"line one\r\nline two\",,",
+		},
+		{
+			logger.Entry{Level: logger.LevelWarning, Context: enc.AppendInt(nil, "order_id", 7),
+				Fields: enc.AppendString(nil, "note", "a, b")},
+			`,warning,,,7,"note=""a, b"""`,
+		},
+		{
+			logger.Entry{Level: logger.LevelWarning, Context: enc.AppendInt(nil, "order_id", 7),
+				Fields: enc.AppendInt(nil, "order_id", 8)},
+			",warning,,,8,",
+		},
+	}
+	for _, test := range tests {
+		if got := string(enc.AppendEntry(nil, test.entry)); got != test.want+"\n" {
+			t.Errorf("AppendEntry() = %q, want %q", got, test.want+"\n")
+		}
+	}
+
+	tsv := logger.NewCSVEncoder(&logger.CSVEncoderConfig{Columns: []string{"level", "message"}, Comma: '\t'})
+	got := string(tsv.AppendEntry(nil, logger.Entry{Level: logger.LevelInfo, Message: []byte("a\tb, c")}))
+	if want := "info\t\"a\tb, c\"\n"; got != want {
+		t.Errorf("AppendEntry() of TSV = %q, want %q", got, want)
+	}
+}
+
+func TestCSVEncoderHeader(t *testing.T) {
+	newConfig := func(w *levelWriters) logger.StandardLoggerConfig {
+		cfg := w.config()
+		cfg.Encoder = logger.NewCSVEncoder(&logger.CSVEncoderConfig{
+			Columns: []string{"level", "message", `order "id"`},
+			Header:  true,
+		})
+		return cfg
+	}
+
+	var w levelWriters
+	cfg := newConfig(&w)
+	log := logger.NewStandardLogger(&cfg)
+	log.Info("first")
+	log.Info("second")
+	log.Error("failed")
+	checkLevelWriters(t, &w, map[string]string{
+		"info":    "level,message,\"order \"\"id\"\"\"\ninfo,first,\ninfo,second,\n",
+		"error":   "level,message,\"order \"\"id\"\"\"\nerror,failed,\n",
+		"warning": "",
+	})
+
+	var unified bytes.Buffer
+	cfg = newConfig(&w)
+	cfg.UnifiedWriter = &unified
+	log = logger.NewStandardLogger(&cfg)
+	log.Info("first")
+	log.Error("failed")
+	if want := "level,message,\"order \"\"id\"\"\"\ninfo,first,\nerror,failed,\n"; unified.String() != want {
+		t.Errorf("the unified writer got %q, want %q", unified.String(), want)
+	}
+}
+
+func TestCSVEncoderHeaderAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs.csv")
+	for _, msg := range []string{"first", "second"} {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		log := logger.NewStandardLogger(&logger.StandardLoggerConfig{
+			InfoWriter: file,
+			Encoder:    logger.NewCSVEncoder(&logger.CSVEncoderConfig{Columns: []string{"message"}, Header: true}),
+		})
+		log.Info(msg)
+		file.Close()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "message\nfirst\nsecond\n"; string(data) != want {
+		t.Errorf("the file reopened for appending has %q, want %q", data, want)
+	}
+}
+
+func TestCSVEncoderHeaderPerWriter(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	cfg.InfoEncoder = logger.NewCSVEncoder(&logger.CSVEncoderConfig{Columns: []string{"message"}, Header: true})
+	cfg.ErrorEncoder = logger.NewCSVEncoder(&logger.CSVEncoderConfig{Columns: []string{"level", "message"}, Header: true})
+	log := logger.NewStandardLogger(&cfg)
+	log.Info("first")
+	log.Error("failed")
+	log.Warning("warned")
+	checkLevelWriters(t, &w, map[string]string{
+		"info":    "message\nfirst\n",
+		"error":   "level,message\nerror,failed\n",
+		"warning": "warned\n",
+	})
+}
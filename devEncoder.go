@@ -0,0 +1,177 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	defaultDevMessageWidth = 40
+	// devLevelWidth is the width of the level column, the length of the longest name of a level.
+	devLevelWidth = 7
+	// devBlockMarker starts and ends the parts of the error blocks in the encoded fields. Text fields never contain it,
+	// as control characters in keys are replaced and values with them are quoted.
+	devBlockMarker = 0
+)
+
+// DevEncoderConfig is the configuration of a DevEncoder.
+type DevEncoderConfig struct {
+	// MessageWidth is the width of the message column. Longer messages are followed by one space. By default, it's 40.
+	MessageWidth int
+	// TimeLayout is the layout of the time. By default, it's "15:04:05.000".
+	TimeLayout string
+}
+
+// DevEncoder encodes entries into lines that are easy to read during development:
+//
+//	12:00:00.000 ERROR   payment failed                           order=42 user=bob
+//	    error: card declined
+//
+// The time is short, the level is padded, the message is padded to a fixed column and the fields follow it.
+// Errors are written as indented blocks below the line, with the stack traces of the errors that print them with
+// "%+v". It is meant for the console; set it as StandardLoggerConfig.ConsoleEncoder and keep a machine format
+// in the files.
+type DevEncoder struct {
+	TextEncoder
+
+	messageWidth int
+	timeLayout   string
+}
+
+// NewDevEncoder creates a new DevEncoder.
+func NewDevEncoder(cfg *DevEncoderConfig) *DevEncoder {
+	enc := &DevEncoder{
+		messageWidth: cfg.MessageWidth,
+		timeLayout:   cfg.TimeLayout,
+	}
+	if enc.messageWidth <= 0 {
+		enc.messageWidth = defaultDevMessageWidth
+	}
+	if enc.timeLayout == "" {
+		enc.timeLayout = "15:04:05.000"
+	}
+	return enc
+}
+
+// isErrorArgNeeded reports that the errors among the args are written as error blocks.
+func (enc *DevEncoder) isErrorArgNeeded() bool {
+	return true
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *DevEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	if entry.Date != nil {
+		dst = entry.Time.AppendFormat(dst, enc.timeLayout)
+		dst = append(dst, ' ')
+	}
+	start := len(dst)
+	if entry.Label != nil {
+		dst = append(dst, entry.Label...)
+	} else {
+		dst = append(dst, strings.ToUpper(entry.Level.String())...)
+	}
+	dst = appendPadding(dst, devLevelWidth+1-(len(dst)-start))
+	if len(entry.Caller) > 0 {
+		dst = append(dst, entry.Caller...)
+		dst = append(dst, ' ')
+	}
+
+	start = len(dst)
+	dst = append(dst, entry.Prefix...)
+	dst = append(dst, entry.Message...)
+	end := len(dst)
+	for i := end - start; i < enc.messageWidth; i++ {
+		dst = append(dst, ' ')
+	}
+	fieldsStart := len(dst)
+	dst = appendInlineFields(dst, entry.Context)
+	dst = appendInlineFields(dst, entry.Fields)
+	if len(dst) == fieldsStart {
+		// no fields to align
+		dst = dst[:end]
+	}
+	dst = append(dst, '\n')
+	dst = appendBlocks(dst, entry.Context)
+	return appendBlocks(dst, entry.Fields)
+}
+
+// appendPadding appends n spaces, but at least one.
+func appendPadding(dst []byte, n int) []byte {
+	if n < 1 {
+		n = 1
+	}
+	for i := 0; i < n; i++ {
+		dst = append(dst, ' ')
+	}
+	return dst
+}
+
+// The error blocks are stored in the encoded fields as the marker, the key, the marker, the text and the marker.
+
+// appendInlineFields appends the fields without the error blocks.
+func appendInlineFields(dst, fields []byte) []byte {
+	for len(fields) > 0 {
+		if fields[0] == devBlockMarker {
+			_, _, fields = nextBlock(fields)
+			continue
+		}
+		end := bytes.IndexByte(fields, devBlockMarker)
+		if end < 0 {
+			end = len(fields)
+		}
+		dst = append(dst, fields[:end]...)
+		fields = fields[end:]
+	}
+	return dst
+}
+
+// nextBlock returns the key and the text of the error block at the beginning of the fields and the rest
+// of the fields.
+func nextBlock(fields []byte) (key, text, rest []byte) {
+	rest = fields[1:]
+	end := bytes.IndexByte(rest, devBlockMarker)
+	key, rest = rest[:end], rest[end+1:]
+	end = bytes.IndexByte(rest, devBlockMarker)
+	return key, rest[:end], rest[end+1:]
+}
+
+// appendBlocks appends the error blocks of the fields, indented by 4 spaces.
+func appendBlocks(dst, fields []byte) []byte {
+	for {
+		start := bytes.IndexByte(fields, devBlockMarker)
+		if start < 0 {
+			return dst
+		}
+		var key, text []byte
+		key, text, fields = nextBlock(fields[start:])
+		dst = append(dst, "    "...)
+		dst = append(dst, key...)
+		dst = append(dst, ": "...)
+		for {
+			end := bytes.IndexByte(text, '\n')
+			if end < 0 {
+				break
+			}
+			dst = append(dst, text[:end+1]...)
+			dst = append(dst, "    "...)
+			text = text[end+1:]
+		}
+		dst = append(dst, text...)
+		dst = append(dst, '\n')
+	}
+}
+
+// AppendError appends the error as a block that is written below the line. The error is printed with "%+v" if it
+// implements fmt.Formatter, to write its stack trace.
+func (enc *DevEncoder) AppendError(dst []byte, key string, err error) []byte {
+	text := err.Error()
+	if _, ok := err.(fmt.Formatter); ok {
+		text = fmt.Sprintf("%+v", err)
+	}
+	dst = append(dst, devBlockMarker)
+	dst = append(dst, strings.ReplaceAll(key, "\x00", "_")...)
+	dst = append(dst, devBlockMarker)
+	dst = append(dst, strings.ReplaceAll(text, "\x00", "")...)
+	return append(dst, devBlockMarker)
+}
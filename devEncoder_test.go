@@ -0,0 +1,57 @@
+package logger_test
+
+import (
+	"errors"
+	"github.com/Eugene-Usachev/logger"
+	"testing"
+	"time"
+)
+
+func TestDevEncoderEntry(t *testing.T) {
+	enc := logger.NewDevEncoder(&logger.DevEncoderConfig{MessageWidth: 16})
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.UTC)
+	tests := []struct {
+		entry logger.Entry
+		want  string
+	}{
+		{
+			logger.Entry{Time: at, Date: []byte("2023/10/01 12:00:00"), Level: logger.LevelInfo,
+				Message: []byte("order created"), Fields: enc.AppendInt(nil, "order", 42)},
+			"12:00:00.123 INFO    order created    order=42\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelWarning, Message: []byte("no fields")},
+			"WARNING no fields\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelSuccess, Label: []byte("OK"), Message: []byte("a message longer than 16"),
+				Context: enc.AppendString(nil, "service", "billing")},
+			"OK      a message longer than 16 service=billing\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelError, Caller: "pay.go:7", Message: []byte("payment failed"),
+				Fields: enc.AppendError(enc.AppendInt(nil, "order", 42), "error", errors.New("card declined"))},
+			"ERROR   pay.go:7 payment failed   order=42\n    error: card declined\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelError, Message: []byte("failed"),
+				Fields: enc.AppendError(nil, "cause", tracedError{})},
+			"ERROR   failed\n    cause: card declined\n    main.pay\n    \tmain.go:42\n",
+		},
+	}
+	for _, test := range tests {
+		if got := string(enc.AppendEntry(nil, test.entry)); got != test.want {
+			t.Errorf("AppendEntry() = %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestDevEncoderErrorArgs(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	cfg.Encoder = logger.NewDevEncoder(&logger.DevEncoderConfig{MessageWidth: 1})
+	logger.NewStandardLogger(&cfg).Error("payment failed: ", errors.New("card declined"))
+	if want := "ERROR   payment failed: card declined\n    error: card declined\n"; w.error.String() != want {
+		t.Errorf("the error writer got %q, want %q", w.error.String(), want)
+	}
+}
@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ecsVersion is the version of the Elastic Common Schema of the logs.
+const ecsVersion = "1.6.0"
+
+// ECSEncoder encodes entries into JSON objects with the field names of the Elastic Common Schema, one per line:
+//
+//	{"@timestamp":"2023-10-01T10:00:00.000Z","log.level":"error","message":"payment failed","ecs.version":"1.6.0","service.name":"billing","process.pid":4242,"error.message":"card declined","error.type":"*errors.errorString"}
+//
+// The caller is written as log.origin.file.name and log.origin.file.line. An error of the "error" or "err" key,
+// including the errors among the args of methods like Error, is written as error.message, error.type and, if the
+// error prints more with "%+v", like the errors with stack traces do, error.stack_trace. Durations are written as
+// numbers of nanoseconds, like event.duration. Other fields are encoded like by JSONEncoder.
+type ECSEncoder struct {
+	JSONEncoder
+
+	// service is the part of every object with the version of ECS, the name of the service and the process id.
+	service []byte
+}
+
+// NewECSEncoder creates a new ECSEncoder. The service name is written as service.name if it is not empty.
+func NewECSEncoder(serviceName string) *ECSEncoder {
+	enc := &ECSEncoder{}
+	enc.service = append(enc.service, `,"ecs.version":"`+ecsVersion+`"`...)
+	if serviceName != "" {
+		enc.service = append(enc.service, `,"service.name":"`...)
+		enc.service = appendJSONString(enc.service, serviceName)
+		enc.service = append(enc.service, '"')
+	}
+	enc.service = append(enc.service, `,"process.pid":`...)
+	enc.service = strconv.AppendInt(enc.service, int64(os.Getpid()), 10)
+	return enc
+}
+
+// isErrorArgNeeded reports that the errors among the args are written as error fields.
+func (enc *ECSEncoder) isErrorArgNeeded() bool {
+	return true
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *ECSEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	dst = append(dst, '{')
+	if entry.Date != nil {
+		dst = append(dst, `"@timestamp":"`...)
+		dst = entry.Time.UTC().AppendFormat(dst, "2006-01-02T15:04:05.000Z07:00")
+		dst = append(dst, `",`...)
+	}
+	dst = append(dst, `"log.level":"`...)
+	if entry.Label != nil {
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Label))
+	} else {
+		dst = append(dst, entry.Level.String()...)
+	}
+	dst = append(dst, '"')
+	if len(entry.Prefix) > 0 || len(entry.Message) > 0 {
+		dst = append(dst, `,"message":"`...)
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Prefix))
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Message))
+		dst = append(dst, '"')
+	}
+	dst = append(dst, enc.service...)
+	if len(entry.Caller) > 0 {
+		file, line := splitCaller(entry.Caller)
+		dst = enc.AppendString(dst, "log.origin.file.name", file)
+		if len(line) > 0 {
+			dst = append(enc.appendKey(dst, "log.origin.file.line"), line...)
+		}
+	}
+	dst = append(dst, entry.Context...)
+	dst = append(dst, entry.Fields...)
+	return append(dst, '}', '\n')
+}
+
+// AppendError appends the error of the "error" or "err" key as error.message, error.type and error.stack_trace,
+// and errors of other keys as strings.
+func (enc *ECSEncoder) AppendError(dst []byte, key string, err error) []byte {
+	if key != "error" && key != "err" {
+		return enc.AppendString(dst, key, err.Error())
+	}
+	msg := err.Error()
+	dst = enc.AppendString(dst, "error.message", msg)
+	dst = enc.AppendString(dst, "error.type", fmt.Sprintf("%T", err))
+	if _, ok := err.(fmt.Formatter); ok {
+		if trace := fmt.Sprintf("%+v", err); trace != msg {
+			dst = enc.AppendString(dst, "error.stack_trace", trace)
+		}
+	}
+	return dst
+}
+
+// AppendDuration appends the duration as a number of nanoseconds, like event.duration of ECS.
+func (enc *ECSEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	return enc.AppendInt(dst, key, int64(value))
+}
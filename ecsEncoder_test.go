@@ -0,0 +1,84 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Eugene-Usachev/logger"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// tracedError is an error that prints a stack trace with "%+v".
+type tracedError struct{}
+
+func (tracedError) Error() string { return "card declined" }
+
+func (e tracedError) Format(s fmt.State, verb rune) {
+	if verb == 'v' && s.Flag('+') {
+		fmt.Fprint(s, "card declined\nmain.pay\n\tmain.go:42")
+		return
+	}
+	fmt.Fprint(s, e.Error())
+}
+
+func TestECSEncoderEntry(t *testing.T) {
+	enc := logger.NewECSEncoder(`bill"ing`)
+	service := `"ecs.version":"1.6.0","service.name":"bill\"ing","process.pid":` + strconv.Itoa(os.Getpid())
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.FixedZone("", 2*60*60))
+	tests := []struct {
+		entry logger.Entry
+		want  string
+	}{
+		{
+			logger.Entry{Time: at, Date: []byte("2023/10/01 12:00:00"), Level: logger.LevelInfo,
+				Message: []byte("order \"created\"\n")},
+			`{"@timestamp":"2023-10-01T10:00:00.123Z","log.level":"info","message":"order \"created\"\n",` + service + `}`,
+		},
+		{
+			logger.Entry{Level: logger.LevelError, Label: []byte("ERR"), Caller: "server/handler.go:42",
+				Fields: enc.AppendError(nil, "error", errors.New("card declined"))},
+			`{"log.level":"ERR",` + service + `,"log.origin.file.name":"server/handler.go","log.origin.file.line":42,` +
+				`"error.message":"card declined","error.type":"*errors.errorString"}`,
+		},
+		{
+			logger.Entry{Level: logger.LevelWarning, Fields: enc.AppendError(nil, "err", tracedError{})},
+			`{"log.level":"warning",` + service + `,"error.message":"card declined","error.type":"logger_test.tracedError",` +
+				`"error.stack_trace":"card declined\nmain.pay\n\tmain.go:42"}`,
+		},
+		{
+			logger.Entry{Level: logger.LevelDebug, Fields: enc.AppendDuration(
+				enc.AppendError(nil, "cause", errors.New(`bad "input"`)), "event.duration", 1500*time.Microsecond)},
+			`{"log.level":"debug",` + service + `,"cause":"bad \"input\"","event.duration":1500000}`,
+		},
+	}
+	for _, test := range tests {
+		got := enc.AppendEntry(nil, test.entry)
+		if string(got) != test.want+"\n" {
+			t.Errorf("AppendEntry() = %s, want %s", got, test.want)
+		}
+		if !json.Valid(got) {
+			t.Errorf("AppendEntry() = %s, want valid JSON", got)
+		}
+	}
+}
+
+func TestECSEncoderErrorArgs(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	cfg.Encoder = logger.NewECSEncoder("")
+	log := logger.NewStandardLogger(&cfg)
+	log.Error("payment failed: ", errors.New("card declined"))
+	log.FormatError("payment %d failed: %v", 42, errors.New("timeout"))
+
+	service := `"ecs.version":"1.6.0","process.pid":` + strconv.Itoa(os.Getpid())
+	want := `{"log.level":"error","message":"payment failed: card declined",` + service +
+		`,"error.message":"card declined","error.type":"*errors.errorString"}` + "\n" +
+		`{"log.level":"error","message":"payment 42 failed: timeout",` + service +
+		`,"error.message":"timeout","error.type":"*errors.errorString"}` + "\n"
+	if w.error.String() != want {
+		t.Errorf("the error writer got %q, want %q", w.error.String(), want)
+	}
+}
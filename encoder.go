@@ -0,0 +1,124 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"time"
+)
+
+// levelRecord is the level of the entries of records. Records are written regardless of the minimum level.
+const levelRecord Level = -1
+
+// Entry is a log to be encoded.
+type Entry struct {
+	// Time is the time of the log. It is zero if the date is not shown.
+	Time time.Time
+	// Date is Time in the date format of the logger. It is nil if the date is not shown.
+	Date []byte
+	// Level is the level of the log. Entries of records have a level with the "record" name.
+	Level Level
+	// Label is the label of the level configured in the logger. It is nil if the level has no label.
+	Label []byte
+	// Caller is the location of the code that logged, like "server/handler.go:42". It is empty if the logger does not
+	// show callers.
+	Caller string
+	// Prefix is the prefix of a record. It is nil for other logs.
+	Prefix []byte
+	// Message is the message of the log. It may be empty for events sent without a message.
+	Message []byte
+	// Context is the fields of the logger, encoded by the encoder when the logger was created by With.
+	Context []byte
+	// Fields is the fields of the log, encoded by the encoder.
+	Fields []byte
+}
+
+// Encoder encodes entries into lines. An encoder encodes the fields of an entry with its Append methods before the
+// entry itself, so the fields of child loggers can be encoded once. The Append methods append one field to the
+// already encoded fields in dst in the format of the encoder.
+//
+// Use one of the encoders of the package, like NewTextEncoder or NewJSONEncoder, or implement your own encoder.
+// An encoder must be safe for concurrent use.
+type Encoder interface {
+	// AppendEntry appends the encoded entry to dst. Text encoders end the entry with a newline.
+	AppendEntry(dst []byte, entry Entry) []byte
+
+	AppendString(dst []byte, key, value string) []byte
+	AppendInt(dst []byte, key string, value int64) []byte
+	AppendUint(dst []byte, key string, value uint64) []byte
+	AppendFloat(dst []byte, key string, value float64) []byte
+	AppendBool(dst []byte, key string, value bool) []byte
+	AppendDuration(dst []byte, key string, value time.Duration) []byte
+	AppendTime(dst []byte, key string, value time.Time) []byte
+	AppendError(dst []byte, key string, err error) []byte
+	// AppendAny appends a field of a type that has no Append method, including nil.
+	AppendAny(dst []byte, key string, value interface{}) []byte
+}
+
+// callerEncoder is an encoder that needs the callers of the logs, even if the logger does not show callers.
+type callerEncoder interface {
+	isCallerNeeded() bool
+}
+
+// appendField appends the field to dst with the Append method of the encoder for the type of the value.
+func appendField(enc Encoder, dst []byte, key string, value interface{}) []byte {
+	switch v := value.(type) {
+	case string:
+		return enc.AppendString(dst, key, v)
+	case []byte:
+		return enc.AppendString(dst, key, fastbytes.B2S(v))
+	case bool:
+		return enc.AppendBool(dst, key, v)
+	case int:
+		return enc.AppendInt(dst, key, int64(v))
+	case int8:
+		return enc.AppendInt(dst, key, int64(v))
+	case int16:
+		return enc.AppendInt(dst, key, int64(v))
+	case int32:
+		return enc.AppendInt(dst, key, int64(v))
+	case int64:
+		return enc.AppendInt(dst, key, v)
+	case uint:
+		return enc.AppendUint(dst, key, uint64(v))
+	case uint8:
+		return enc.AppendUint(dst, key, uint64(v))
+	case uint16:
+		return enc.AppendUint(dst, key, uint64(v))
+	case uint32:
+		return enc.AppendUint(dst, key, uint64(v))
+	case uint64:
+		return enc.AppendUint(dst, key, v)
+	case uintptr:
+		return enc.AppendUint(dst, key, uint64(v))
+	case float32:
+		return enc.AppendFloat(dst, key, float64(v))
+	case float64:
+		return enc.AppendFloat(dst, key, v)
+	case time.Duration:
+		return enc.AppendDuration(dst, key, v)
+	case time.Time:
+		return enc.AppendTime(dst, key, v)
+	case error:
+		return enc.AppendError(dst, key, v)
+	case fmt.Stringer:
+		return enc.AppendString(dst, key, v.String())
+	default:
+		return enc.AppendAny(dst, key, v)
+	}
+}
+
+// appendKeyValues appends the alternating keys and values to dst as fields. A key without a value is written as
+// the value of the "!BADKEY" key.
+func appendKeyValues(enc Encoder, dst []byte, keysAndValues []interface{}) []byte {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		if i+1 == len(keysAndValues) {
+			return appendField(enc, dst, "!BADKEY", keysAndValues[i])
+		}
+		key, ok := keysAndValues[i].(string)
+		if !ok {
+			key = fmt.Sprint(keysAndValues[i])
+		}
+		dst = appendField(enc, dst, key, keysAndValues[i+1])
+	}
+	return dst
+}
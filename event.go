@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"sync"
+	"time"
+)
+
+// Event is a log under construction. Get it from a method like StandardLogger.InfoEvent or FastLogger.InfoEvent,
+// add fields with typed methods and write it with Msg, Msgf or Send. The fields are written straight into a pooled
+// Record without boxing the values, so a chain does not allocate.
+//
+// An Event is nil if its level is disabled; all methods of a nil Event do nothing. An Event must not be used after Msg,
+// Msgf or Send.
+//
+// Example:
+//
+//	logger.InfoEvent().Str("user", name).Int("attempt", 3).Dur("took", took).Err(err).Msg("done")
+type Event struct {
+	// record keeps the fields, encoded by the encoder.
+	record  *Record
+	encoder Encoder
+	level   Level
+	logger  entryLogger
+}
+
+var eventPool = sync.Pool{
+	New: func() any {
+		return &Event{}
+	},
+}
+
+func newEvent(level Level, logger entryLogger) *Event {
+	e := eventPool.Get().(*Event)
+	e.record = Builder()
+	e.encoder = logger.fieldEncoder()
+	e.level = level
+	e.logger = logger
+	return e
+}
+
+// Str adds the field with the string value.
+func (e *Event) Str(key, value string) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendString(e.record.rec, key, value)
+	return e
+}
+
+// Bytes adds the field with the value as a string.
+func (e *Event) Bytes(key string, value []byte) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendString(e.record.rec, key, fastbytes.B2S(value))
+	return e
+}
+
+// Int adds the field with the int value.
+func (e *Event) Int(key string, value int) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendInt(e.record.rec, key, int64(value))
+	return e
+}
+
+// Int64 adds the field with the int64 value.
+func (e *Event) Int64(key string, value int64) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendInt(e.record.rec, key, value)
+	return e
+}
+
+// Uint adds the field with the uint value.
+func (e *Event) Uint(key string, value uint) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendUint(e.record.rec, key, uint64(value))
+	return e
+}
+
+// Uint64 adds the field with the uint64 value.
+func (e *Event) Uint64(key string, value uint64) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendUint(e.record.rec, key, value)
+	return e
+}
+
+// Float64 adds the field with the float64 value.
+func (e *Event) Float64(key string, value float64) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendFloat(e.record.rec, key, value)
+	return e
+}
+
+// Bool adds the field with the bool value.
+func (e *Event) Bool(key string, value bool) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendBool(e.record.rec, key, value)
+	return e
+}
+
+// Dur adds the field with the duration.
+func (e *Event) Dur(key string, value time.Duration) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendDuration(e.record.rec, key, value)
+	return e
+}
+
+// Time adds the field with the time.
+func (e *Event) Time(key string, value time.Time) *Event {
+	if e == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendTime(e.record.rec, key, value)
+	return e
+}
+
+// Err adds the "error" field with the message of the err. It does nothing if the err is nil.
+func (e *Event) Err(err error) *Event {
+	if e == nil || err == nil {
+		return e
+	}
+	e.record.rec = e.encoder.AppendError(e.record.rec, "error", err)
+	return e
+}
+
+// Msg writes the event with the message.
+func (e *Event) Msg(msg string) {
+	if e == nil {
+		return
+	}
+	e.logger.logEntry(e.level, fastbytes.S2B(msg), e.record.rec)
+	e.record.release()
+	e.record = nil
+	e.encoder = nil
+	e.logger = nil
+	eventPool.Put(e)
+}
+
+// Msgf writes the event with the message with format.
+func (e *Event) Msgf(f string, args ...interface{}) {
+	if e == nil {
+		return
+	}
+	e.Msg(fmt.Sprintf(f, args...))
+}
+
+// Send writes the event without a message.
+func (e *Event) Send() {
+	e.Msg("")
+}
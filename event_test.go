@@ -0,0 +1,66 @@
+package logger_test
+
+import (
+	"bytes"
+	"errors"
+	"github.com/Eugene-Usachev/logger"
+	"testing"
+	"time"
+)
+
+func TestEvent(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		log  func(log *logger.StandardLogger)
+		want string
+	}{
+		{
+			func(log *logger.StandardLogger) {
+				log.InfoEvent().Str("user", "bob smith").Int("id", -42).Uint64("size", 1<<40).Msg("order created")
+			},
+			`order created user="bob smith" id=-42 size=1099511627776` + "\n",
+		},
+		{
+			func(log *logger.StandardLogger) {
+				log.InfoEvent().Float64("ratio", 0.5).Bool("ok", true).Dur("took", 1500*time.Microsecond).Time("at", at).Send()
+			},
+			"ratio=0.5 ok=true took=1.5ms at=2023-10-01T12:00:00Z\n",
+		},
+		{
+			func(log *logger.StandardLogger) {
+				log.InfoEvent().Err(errors.New("card declined")).Err(nil).Msgf("payment %d failed", 7)
+			},
+			`payment 7 failed error="card declined"` + "\n",
+		},
+		{
+			func(log *logger.StandardLogger) {
+				log.With("service", "billing").InfoEvent().Bytes("raw", []byte("a=b")).Msg("")
+			},
+			`service=billing raw="a=b"` + "\n",
+		},
+		{
+			func(log *logger.StandardLogger) {
+				log.DebugEvent().Str("dropped", "yes").Msg("disabled")
+			},
+			"",
+		},
+	}
+	for i, test := range tests {
+		var buf bytes.Buffer
+		log := logger.NewStandardLogger(&logger.StandardLoggerConfig{InfoWriter: &buf, DebugWriter: &buf, Level: logger.LevelInfo})
+		test.log(log)
+		if got := buf.String(); got != test.want {
+			t.Errorf("event %d = %q, want %q", i, got, test.want)
+		}
+	}
+}
+
+func TestFastLoggerEvent(t *testing.T) {
+	var w levelWriters
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: w.config(), FlushInterval: time.Hour})
+	defer fastLogger.Stop()
+	fastLogger.ErrorEvent().Int("id", 1).Msg("first")
+	fastLogger.ErrorEvent().Int("id", 2).Msg("second")
+	fastLogger.Flush()
+	checkLevelWriters(t, &w, map[string]string{"error": "first id=1\nsecond id=2\n"})
+}
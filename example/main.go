@@ -45,6 +45,14 @@ func main() {
 	fatalFile, _ := os.OpenFile(filePathFatal, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
 	defer fatalFile.Close()
 
+	filePathDebug := filepath.Join(logsDir, "debug.txt")
+	debugFile, _ := os.OpenFile(filePathDebug, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	defer debugFile.Close()
+
+	filePathTrace := filepath.Join(logsDir, "trace.txt")
+	traceFile, _ := os.OpenFile(filePathTrace, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0666)
+	defer traceFile.Close()
+
 	cfg := &testLogger.StandardLoggerConfig{
 		IsWritingToTheConsole: true,
 		ErrorWriter:           errorFile,
@@ -54,11 +62,15 @@ func main() {
 		FatalWriter:           fatalFile,
 		RecordWriter:          recordFile,
 		RawWriter:             rawFile,
+		DebugWriter:           debugFile,
+		TraceWriter:           traceFile,
 		ShowDate:              true,
 	}
 
 	logger := testLogger.NewStandardLogger(cfg)
 
+	logger.Trace("[trace std] Hello, World!")
+	logger.Debug("[debug std] Hello, World!")
 	logger.Info("[info std] Hello, World!")
 	logger.Error("[error std] Hello, World!")
 	logger.Warning("[warning std] Hello, World!")
@@ -72,6 +84,8 @@ func main() {
 		FatalFunc:            nil,
 	})
 
+	fastLogger.Trace("[trace fast] Hello, World!")
+	fastLogger.Debug("[debug fast] Hello, World!")
 	fastLogger.Info("[info fast] Hello, World!")
 	fastLogger.Error("[error fast] Hello, World!")
 	fastLogger.Warning("[warning fast] Hello, World!")
@@ -1,7 +1,7 @@
 package logger
 
 import (
-	"fmt"
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -25,6 +25,8 @@ Example:
 			FatalWriter:           fatalFile,
 			RecordWriter:          recordFile,
 			RawWriter:             rawFile,
+			DebugWriter:           debugFile,
+			TraceWriter:           traceFile,
 		},
 		FlushInterval: 1 * time.Second,
 		FatalFunc:     func(reason any) {
@@ -35,24 +37,55 @@ Example:
 type FastLogger struct {
 	stdLogger *StandardLogger
 
-	isRunning atomic.Bool
-
-	infoLogs     []byte
-	infoMutex    sync.Mutex
-	errorLogs    []byte
-	errorMutex   sync.Mutex
-	warningLogs  []byte
-	warningMutex sync.Mutex
-	successLogs  []byte
-	successMutex sync.Mutex
-	recordLogs   []byte
-	recordMutex  sync.Mutex
-	rawLogs      []byte
-	rawMutex     sync.Mutex
+	// buffers are shared between the logger and its children.
+	buffers *fastBuffers
 
 	fatalFunc func(reason any)
 }
 
+// logBuffer is a buffer of logs that are not flushed yet.
+type logBuffer struct {
+	logs []byte
+	// spans are the levels of the logs in the unified buffer, to color them in the console.
+	spans []levelSpan
+	mutex sync.Mutex
+}
+
+// levelSpan is a part of the logs of a buffer with logs of one level. It starts at the end of the previous span.
+type levelSpan struct {
+	level Level
+	end   int
+}
+
+// levelRaw is the level of the spans of raw logs. Raw logs are not colored.
+const levelRaw Level = -2
+
+// markLevel marks the logs appended after the previous span as logs of the level.
+func (buffer *logBuffer) markLevel(level Level) {
+	if last := len(buffer.spans) - 1; last >= 0 && buffer.spans[last].level == level {
+		buffer.spans[last].end = len(buffer.logs)
+		return
+	}
+	buffer.spans = append(buffer.spans, levelSpan{level: level, end: len(buffer.logs)})
+}
+
+// fastBuffers are the buffers of a FastLogger and its children.
+type fastBuffers struct {
+	isRunning atomic.Bool
+	// stopped is closed when the logger is stopped, to wake the flushing goroutine up.
+	stopped  chan struct{}
+	stopOnce sync.Once
+
+	// levels are the buffers of the levels from LevelTrace to LevelError. Fatal logs are written without buffering.
+	levels [LevelFatal]logBuffer
+	record logBuffer
+	raw    logBuffer
+	// unified is the buffer of all logs in the unified mode.
+	unified logBuffer
+	// console is the buffer of the logs for the console when the console has its own encoder.
+	console logBuffer
+}
+
 type FastLoggerConfig struct {
 	StandardLoggerConfig
 
@@ -65,30 +98,24 @@ type FastLoggerConfig struct {
 // NewFastLogger creates a new FastLogger.
 func NewFastLogger(cfg *FastLoggerConfig) *FastLogger {
 	logger := &FastLogger{
-		stdLogger:    NewStandardLogger(&cfg.StandardLoggerConfig),
-		isRunning:    atomic.Bool{},
-		infoLogs:     make([]byte, 0),
-		infoMutex:    sync.Mutex{},
-		errorLogs:    make([]byte, 0),
-		errorMutex:   sync.Mutex{},
-		warningLogs:  make([]byte, 0),
-		warningMutex: sync.Mutex{},
-		successLogs:  make([]byte, 0),
-		successMutex: sync.Mutex{},
-		recordLogs:   make([]byte, 0),
-		recordMutex:  sync.Mutex{},
-		rawLogs:      make([]byte, 0),
-		rawMutex:     sync.Mutex{},
-		fatalFunc:    cfg.FatalFunc,
-	}
-
-	logger.isRunning.Store(true)
+		stdLogger: NewStandardLogger(&cfg.StandardLoggerConfig),
+		buffers:   &fastBuffers{stopped: make(chan struct{})},
+		fatalFunc: cfg.FatalFunc,
+	}
+
+	logger.buffers.isRunning.Store(true)
 	interval := cfg.FlushInterval
 	go func() {
+		timer := time.NewTimer(interval)
+		defer timer.Stop()
 		for {
-			time.Sleep(interval)
+			select {
+			case <-timer.C:
+				timer.Reset(interval)
+			case <-logger.buffers.stopped:
+			}
 			logger.Flush()
-			if !logger.isRunning.Load() {
+			if !logger.buffers.isRunning.Load() {
 				break
 			}
 		}
@@ -97,240 +124,532 @@ func NewFastLogger(cfg *FastLoggerConfig) *FastLogger {
 	return logger
 }
 
+// With returns a child logger that writes the fields to every log. The fields are given as alternating keys and values
+// and are encoded once, here. The child shares the writers, the level and the buffers with the logger,
+// so it does not start a new flushing goroutine.
+func (logger *FastLogger) With(keysAndValues ...interface{}) *FastLogger {
+	return &FastLogger{
+		stdLogger: logger.stdLogger.With(keysAndValues...),
+		buffers:   logger.buffers,
+		fatalFunc: logger.fatalFunc,
+	}
+}
+
 // Flush flushes all logs to the logger.
 func (logger *FastLogger) Flush() {
+	for level := LevelTrace; level < LevelFatal; level++ {
+		logger.flushBuffer(&logger.buffers.levels[level], func(buf []byte) {
+			logger.stdLogger.write(level, buf)
+		})
+	}
+	logger.flushBuffer(&logger.buffers.record, func(buf []byte) {
+		logger.stdLogger.write(levelRecord, buf)
+	})
+	logger.flushBuffer(&logger.buffers.raw, func(buf []byte) {
+		logger.stdLogger.write(levelRaw, buf)
+	})
+	logger.flushBuffer(&logger.buffers.unified, func(buf []byte) {
+		logger.stdLogger.writeUnified(buf, logger.buffers.unified.spans)
+	})
+	logger.flushBuffer(&logger.buffers.console, func(buf []byte) {
+		logger.stdLogger.writeConsoleSpans(buf, logger.buffers.console.spans)
+	})
+}
+
+// flushBuffer writes the logs of the buffer with the write function and clears the buffer.
+// The logs are dropped if the write function panics.
+func (logger *FastLogger) flushBuffer(buffer *logBuffer, write func(buf []byte)) {
+	buffer.mutex.Lock()
 	defer func() {
+		buffer.logs = buffer.logs[:0]
+		buffer.spans = buffer.spans[:0]
+		buffer.mutex.Unlock()
 		if err := recover(); err != nil {
+			if logger.fatalFunc == nil {
+				panic(err)
+			}
 			logger.fatalFunc(err)
-			logger.infoLogs = logger.infoLogs[:0]
-			logger.infoMutex.Unlock()
-			logger.errorLogs = logger.errorLogs[:0]
-			logger.errorMutex.Unlock()
-			logger.warningLogs = logger.warningLogs[:0]
-			logger.warningMutex.Unlock()
-			logger.successLogs = logger.successLogs[:0]
-			logger.successMutex.Unlock()
 		}
 	}()
-	{
-		logger.infoMutex.Lock()
-		if len(logger.infoLogs) > 0 {
-			logger.stdLogger.info(logger.infoLogs)
-			logger.infoLogs = logger.infoLogs[:0]
-		}
-		logger.infoMutex.Unlock()
+	if len(buffer.logs) > 0 {
+		write(buffer.logs)
 	}
-	{
-		logger.errorMutex.Lock()
-		if len(logger.errorLogs) > 0 {
-			logger.stdLogger.error(logger.errorLogs)
-			logger.errorLogs = logger.errorLogs[:0]
-		}
-		logger.errorMutex.Unlock()
+}
+
+// Stop stops the logger. The logs are flushed once more right away, without waiting for the flush interval.
+func (logger *FastLogger) Stop() {
+	logger.buffers.stop()
+}
+
+// stop stops the flushing goroutine after its next flush and wakes it up.
+func (buffers *fastBuffers) stop() {
+	buffers.isRunning.Store(false)
+	buffers.stopOnce.Do(func() {
+		close(buffers.stopped)
+	})
+}
+
+// StopWithoutFlush stops the logger without flushing. WILL CLEAR NOT FLUSHED LOGS!
+func (logger *FastLogger) StopWithoutFlush() {
+	buffers := logger.buffers
+	for level := range buffers.levels {
+		buffers.levels[level].mutex.Lock()
 	}
-	{
-		logger.warningMutex.Lock()
-		if len(logger.warningLogs) > 0 {
-			logger.stdLogger.warning(logger.warningLogs)
-			logger.warningLogs = logger.warningLogs[:0]
-		}
-		logger.warningMutex.Unlock()
+	buffers.record.mutex.Lock()
+	buffers.raw.mutex.Lock()
+	buffers.unified.mutex.Lock()
+	buffers.console.mutex.Lock()
+	for level := range buffers.levels {
+		buffers.levels[level].logs = buffers.levels[level].logs[:0]
 	}
-	{
-		logger.successMutex.Lock()
-		if len(logger.successLogs) > 0 {
-			logger.stdLogger.success(logger.successLogs)
-			logger.successLogs = logger.successLogs[:0]
-		}
-		logger.successMutex.Unlock()
+	buffers.record.logs = buffers.record.logs[:0]
+	buffers.raw.logs = buffers.raw.logs[:0]
+	buffers.unified.logs = buffers.unified.logs[:0]
+	buffers.unified.spans = buffers.unified.spans[:0]
+	buffers.console.logs = buffers.console.logs[:0]
+	buffers.console.spans = buffers.console.spans[:0]
+	for level := range buffers.levels {
+		buffers.levels[level].mutex.Unlock()
 	}
-	{
-		logger.recordMutex.Lock()
-		if len(logger.recordLogs) > 0 {
-			logger.stdLogger.record(logger.recordLogs)
-			logger.recordLogs = logger.recordLogs[:0]
+	buffers.record.mutex.Unlock()
+	buffers.raw.mutex.Unlock()
+	buffers.unified.mutex.Unlock()
+	buffers.console.mutex.Unlock()
+	buffers.stop()
+}
+
+// SetLevel sets the minimum level of logs to be written. It is safe to call it while the logger is in use.
+func (logger *FastLogger) SetLevel(level Level) {
+	logger.stdLogger.SetLevel(level)
+}
+
+// Level returns the minimum level of logs to be written.
+func (logger *FastLogger) Level() Level {
+	return logger.stdLogger.Level()
+}
+
+// Record logs a record to the logger.stdLogger.recordWriter.
+func (logger *FastLogger) Record(record *Record) {
+	logger.logRecord(levelRecord, record)
+	record.release()
+}
+
+// Raw logs a raw log to the logger.stdLogger.rawWriter. Raw logs are written as is, without encoding.
+func (logger *FastLogger) Raw(data []byte) {
+	if logger.stdLogger.separateConsole {
+		buffer := &logger.buffers.console
+		buffer.mutex.Lock()
+		buffer.logs = append(buffer.logs, data...)
+		buffer.markLevel(levelRaw)
+		buffer.mutex.Unlock()
+		if logger.stdLogger.rawWriter == nil {
+			return
 		}
-		logger.recordMutex.Unlock()
 	}
-	{
-		logger.rawMutex.Lock()
-		if len(logger.rawLogs) > 0 {
-			logger.stdLogger.raw(logger.rawLogs)
-			logger.rawLogs = logger.rawLogs[:0]
+	buffer := logger.buffer(levelRaw)
+	buffer.mutex.Lock()
+	buffer.logs = append(buffer.logs, data...)
+	buffer.markLevel(levelRaw)
+	buffer.mutex.Unlock()
+}
+
+// isEnabled reports whether logs of the level should be written.
+func (logger *FastLogger) isEnabled(level Level) bool {
+	return logger.stdLogger.isEnabled(level)
+}
+
+// fieldEncoder returns the encoder of the fields of the logs.
+func (logger *FastLogger) fieldEncoder() Encoder {
+	return logger.stdLogger.fieldEncoder()
+}
+
+// buffer returns the buffer of the level. All levels share the unified buffer in the unified mode.
+func (logger *FastLogger) buffer(level Level) *logBuffer {
+	switch {
+	case logger.stdLogger.unifiedWriter != nil:
+		return &logger.buffers.unified
+	case level == levelRecord:
+		return &logger.buffers.record
+	case level == levelRaw:
+		return &logger.buffers.raw
+	default:
+		return &logger.buffers.levels[level]
+	}
+}
+
+// logEntry logs the message and the encoded fields with the level. Fatal logs are written after flushing without
+// buffering.
+func (logger *FastLogger) logEntry(level Level, msg, fields []byte) {
+	logger.logEntryAt(level, time.Time{}, msg, fields)
+}
+
+// logEntryAt logs the message and the encoded fields with the level, dated with the time t instead of the time of the
+// clock if t is not zero. Fatal logs are written after flushing without buffering.
+func (logger *FastLogger) logEntryAt(level Level, t time.Time, msg, fields []byte) {
+	if level == LevelFatal {
+		logger.Flush()
+		logger.stdLogger.logEntryAt(level, t, msg, fields)
+		return
+	}
+	date := Builder()
+	logger.bufferEntry(level, logger.stdLogger.newEntry(level, logger.stdLogger.showDate, t, nil, msg, date), fields, true)
+	date.release()
+}
+
+// logFormatted logs the formatted message with the level. The newline the encoder ends the log with is dropped, so the
+// log ends with a newline only if the message does. Fatal logs are written after flushing without buffering.
+func (logger *FastLogger) logFormatted(level Level, msg []byte) {
+	if level == LevelFatal {
+		logger.Flush()
+		logger.stdLogger.logFormatted(level, msg)
+		return
+	}
+	date := Builder()
+	entry := logger.stdLogger.newEntry(level, logger.stdLogger.showDate, time.Time{}, nil, msg, date)
+	logger.bufferEntry(level, entry, nil, false)
+	date.release()
+}
+
+// logRecord logs the built or prepared record with the level. Fatal logs are written after flushing without buffering.
+func (logger *FastLogger) logRecord(level Level, record *Record) {
+	if level == LevelFatal {
+		logger.Flush()
+		logger.stdLogger.logRecord(level, record)
+		return
+	}
+	date := Builder()
+	entry := logger.stdLogger.newEntry(level, record.isShowDate, time.Time{}, record.prefix, record.rec, date)
+	logger.bufferEntry(level, entry, nil, record.isNewLine)
+	date.release()
+}
+
+// bufferEntry appends the entry with the fields to the buffer of the level and, if the console has its own encoding,
+// to the console buffer. The console buffer reuses the encoded entry if the console and the writer share the encoder.
+func (logger *FastLogger) bufferEntry(level Level, entry Entry, fields []byte, isNewLine bool) {
+	stdLogger := logger.stdLogger
+	console := &logger.buffers.console
+	isShared := stdLogger.separateConsole && !stdLogger.isConsoleSeparate(level)
+	hasWriter := stdLogger.writer(level) != nil
+	if hasWriter || !stdLogger.separateConsole {
+		buffer := logger.buffer(level)
+		buffer.mutex.Lock()
+		start := len(buffer.logs)
+		buffer.logs = stdLogger.appendEntry(buffer.logs, stdLogger.writerEncoder(level), entry, fields, isNewLine)
+		buffer.markLevel(level)
+		if isShared {
+			console.mutex.Lock()
+			console.logs = append(console.logs, buffer.logs[start:]...)
+			console.markLevel(level)
+			console.mutex.Unlock()
 		}
-		logger.rawMutex.Unlock()
+		buffer.mutex.Unlock()
+	}
+	if stdLogger.separateConsole && !(isShared && hasWriter) {
+		console.mutex.Lock()
+		console.logs = stdLogger.appendEntry(console.logs, stdLogger.consoleEncoder, entry, fields, isNewLine)
+		console.markLevel(level)
+		console.mutex.Unlock()
 	}
 }
 
-// Stop stops the logger.
-func (logger *FastLogger) Stop() {
-	logger.isRunning.Store(false)
+// Trace logs a message to the logger.stdLogger.traceWriter.
+func (logger *FastLogger) Trace(args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelTrace) {
+		return
+	}
+	logArgs(logger, LevelTrace, args)
 }
 
-// StopWithoutFlush stops the logger without flushing. WILL CLEAR NOT FLUSHED LOGS!
-func (logger *FastLogger) StopWithoutFlush() {
-	logger.infoMutex.Lock()
-	logger.errorMutex.Lock()
-	logger.warningMutex.Lock()
-	logger.successMutex.Lock()
-	logger.recordMutex.Lock()
-	logger.rawMutex.Lock()
-	logger.infoLogs = logger.infoLogs[:0]
-	logger.errorLogs = logger.errorLogs[:0]
-	logger.warningLogs = logger.warningLogs[:0]
-	logger.successLogs = logger.successLogs[:0]
-	logger.recordLogs = logger.recordLogs[:0]
-	logger.rawLogs = logger.rawLogs[:0]
-	logger.infoMutex.Unlock()
-	logger.errorMutex.Unlock()
-	logger.warningMutex.Unlock()
-	logger.successMutex.Unlock()
-	logger.recordMutex.Unlock()
-	logger.rawMutex.Unlock()
-	logger.isRunning.Store(false)
+// FormatTrace logs a message with format to the logger.stdLogger.traceWriter.
+func (logger *FastLogger) FormatTrace(f string, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelTrace) {
+		return
+	}
+	logFormat(logger, LevelTrace, f, args)
 }
 
-// Record logs a record to the logger.stdLogger.recordWriter.
-func (logger *FastLogger) Record(record *Record) {
-	logger.recordMutex.Lock()
-	logger.recordLogs = append(logger.recordLogs, record.rec...)
-	record.Reset()
-	if record.wasGot {
-		recordPool.Put(record)
+// TraceKV logs a message with fields to the logger.stdLogger.traceWriter. The fields are given as alternating keys
+// and values.
+func (logger *FastLogger) TraceKV(msg string, keysAndValues ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelTrace) {
+		return
 	}
-	logger.recordMutex.Unlock()
+	logKV(logger, LevelTrace, msg, keysAndValues)
 }
 
-// Raw logs a raw log to the logger.stdLogger.rawWriter.
-func (logger *FastLogger) Raw(data []byte) {
-	logger.rawMutex.Lock()
-	logger.rawLogs = append(logger.rawLogs, data...)
-	logger.rawMutex.Unlock()
+// TraceCtx logs a message with the fields of the registered context keys to the logger.stdLogger.traceWriter.
+func (logger *FastLogger) TraceCtx(ctx context.Context, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelTrace) {
+		return
+	}
+	logCtx(logger, LevelTrace, ctx, args)
 }
 
-// Info logs a message to the logger.stdLogger.infoWriter.
+// TraceEvent returns an Event that will be written to the logger.stdLogger.traceWriter. It returns nil if LevelTrace is
+// disabled.
+func (logger *FastLogger) TraceEvent() *Event {
+	if !logger.stdLogger.isEnabled(LevelTrace) {
+		return nil
+	}
+	return newEvent(LevelTrace, logger)
+}
+
+// TracePrepare logs a prepared record to the logger.traceWriter. Will not reset the record.
+func (logger *FastLogger) TracePrepare(record *Record) {
+	if !logger.stdLogger.isEnabled(LevelTrace) {
+		return
+	}
+	logger.logRecord(LevelTrace, record)
+}
+
+// Debug logs a message to the logger.stdLogger.debugWriter.
+func (logger *FastLogger) Debug(args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelDebug) {
+		return
+	}
+	logArgs(logger, LevelDebug, args)
+}
+
+// FormatDebug logs a message with format to the logger.stdLogger.debugWriter.
+func (logger *FastLogger) FormatDebug(f string, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelDebug) {
+		return
+	}
+	logFormat(logger, LevelDebug, f, args)
+}
+
+// DebugKV logs a message with fields to the logger.stdLogger.debugWriter. The fields are given as alternating keys
+// and values.
+func (logger *FastLogger) DebugKV(msg string, keysAndValues ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelDebug) {
+		return
+	}
+	logKV(logger, LevelDebug, msg, keysAndValues)
+}
+
+// DebugCtx logs a message with the fields of the registered context keys to the logger.stdLogger.debugWriter.
+func (logger *FastLogger) DebugCtx(ctx context.Context, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelDebug) {
+		return
+	}
+	logCtx(logger, LevelDebug, ctx, args)
+}
 
+// DebugEvent returns an Event that will be written to the logger.stdLogger.debugWriter. It returns nil if LevelDebug is
+// disabled.
+func (logger *FastLogger) DebugEvent() *Event {
+	if !logger.stdLogger.isEnabled(LevelDebug) {
+		return nil
+	}
+	return newEvent(LevelDebug, logger)
+}
+
+// DebugPrepare logs a prepared record to the logger.debugWriter. Will not reset the record.
+func (logger *FastLogger) DebugPrepare(record *Record) {
+	if !logger.stdLogger.isEnabled(LevelDebug) {
+		return
+	}
+	logger.logRecord(LevelDebug, record)
+}
+
+// Info logs a message to the logger.stdLogger.infoWriter.
 func (logger *FastLogger) Info(args ...interface{}) {
-	logger.infoMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.infoLogs = append(logger.infoLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelInfo) {
+		return
 	}
-	logger.infoLogs = addArgsToLog(logger.infoLogs, args...)
-	logger.infoLogs = append(logger.infoLogs, '\n')
-	logger.infoMutex.Unlock()
+	logArgs(logger, LevelInfo, args)
 }
 
 // FormatInfo logs a message with format to the logger.stdLogger.infoWriter.
 func (logger *FastLogger) FormatInfo(f string, args ...interface{}) {
-	logger.infoMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.infoLogs = append(logger.infoLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelInfo) {
+		return
+	}
+	logFormat(logger, LevelInfo, f, args)
+}
+
+// InfoKV logs a message with fields to the logger.stdLogger.infoWriter. The fields are given as alternating keys
+// and values.
+func (logger *FastLogger) InfoKV(msg string, keysAndValues ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelInfo) {
+		return
 	}
-	logger.infoLogs = append(logger.infoLogs, fmt.Sprintf(f, args...)...)
-	logger.infoMutex.Unlock()
+	logKV(logger, LevelInfo, msg, keysAndValues)
+}
+
+// InfoCtx logs a message with the fields of the registered context keys to the logger.stdLogger.infoWriter.
+func (logger *FastLogger) InfoCtx(ctx context.Context, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelInfo) {
+		return
+	}
+	logCtx(logger, LevelInfo, ctx, args)
+}
+
+// InfoEvent returns an Event that will be written to the logger.stdLogger.infoWriter. It returns nil if LevelInfo is
+// disabled.
+func (logger *FastLogger) InfoEvent() *Event {
+	if !logger.stdLogger.isEnabled(LevelInfo) {
+		return nil
+	}
+	return newEvent(LevelInfo, logger)
 }
 
 // InfoPrepare logs a prepared record to the logger.infoWriter. Will not reset the record.
 func (logger *FastLogger) InfoPrepare(record *Record) {
-	logger.infoMutex.Lock()
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.stdLogger.isEnabled(LevelInfo) {
+		return
 	}
-	logger.infoLogs = append(logger.infoLogs, record.rec...)
-	logger.infoMutex.Unlock()
+	logger.logRecord(LevelInfo, record)
 }
 
 // Error logs a message to the logger.stdLogger.errorWriter.
 func (logger *FastLogger) Error(args ...interface{}) {
-	logger.errorMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.errorLogs = append(logger.errorLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelError) {
+		return
 	}
-	logger.errorLogs = addArgsToLog(logger.errorLogs, args...)
-	logger.errorLogs = append(logger.errorLogs, '\n')
-	logger.errorMutex.Unlock()
+	logArgs(logger, LevelError, args)
 }
 
 // FormatError logs a message with format to the logger.stdLogger.errorWriter.
 func (logger *FastLogger) FormatError(f string, args ...interface{}) {
-	logger.errorMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.errorLogs = append(logger.errorLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelError) {
+		return
+	}
+	logFormat(logger, LevelError, f, args)
+}
+
+// ErrorKV logs a message with fields to the logger.stdLogger.errorWriter. The fields are given as alternating keys
+// and values.
+func (logger *FastLogger) ErrorKV(msg string, keysAndValues ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelError) {
+		return
 	}
-	logger.errorLogs = append(logger.errorLogs, fmt.Sprintf(f, args...)...)
-	logger.errorMutex.Unlock()
+	logKV(logger, LevelError, msg, keysAndValues)
+}
+
+// ErrorCtx logs a message with the fields of the registered context keys to the logger.stdLogger.errorWriter.
+func (logger *FastLogger) ErrorCtx(ctx context.Context, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelError) {
+		return
+	}
+	logCtx(logger, LevelError, ctx, args)
+}
+
+// ErrorEvent returns an Event that will be written to the logger.stdLogger.errorWriter. It returns nil if LevelError is
+// disabled.
+func (logger *FastLogger) ErrorEvent() *Event {
+	if !logger.stdLogger.isEnabled(LevelError) {
+		return nil
+	}
+	return newEvent(LevelError, logger)
 }
 
 // ErrorPrepare logs a prepared record to the logger.errorWriter. Will not reset the record.
 func (logger *FastLogger) ErrorPrepare(record *Record) {
-	logger.errorMutex.Lock()
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.stdLogger.isEnabled(LevelError) {
+		return
 	}
-	logger.errorLogs = append(logger.errorLogs, record.rec...)
-	logger.errorMutex.Unlock()
+	logger.logRecord(LevelError, record)
 }
 
 // Warning logs a message to the logger.stdLogger.warningWriter.
 func (logger *FastLogger) Warning(args ...interface{}) {
-	logger.warningMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.warningLogs = append(logger.warningLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelWarning) {
+		return
 	}
-	logger.warningLogs = addArgsToLog(logger.warningLogs, args...)
-	logger.warningLogs = append(logger.warningLogs, '\n')
-	logger.warningMutex.Unlock()
+	logArgs(logger, LevelWarning, args)
 }
 
 // FormatWarning logs a message with format to the logger.stdLogger.warningWriter.
 func (logger *FastLogger) FormatWarning(f string, args ...interface{}) {
-	logger.warningMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.warningLogs = append(logger.warningLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelWarning) {
+		return
 	}
-	logger.warningLogs = append(logger.warningLogs, fmt.Sprintf(f, args...)...)
-	logger.warningMutex.Unlock()
+	logFormat(logger, LevelWarning, f, args)
+}
+
+// WarningKV logs a message with fields to the logger.stdLogger.warningWriter. The fields are given as alternating keys
+// and values.
+func (logger *FastLogger) WarningKV(msg string, keysAndValues ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelWarning) {
+		return
+	}
+	logKV(logger, LevelWarning, msg, keysAndValues)
+}
+
+// WarningCtx logs a message with the fields of the registered context keys to the logger.stdLogger.warningWriter.
+func (logger *FastLogger) WarningCtx(ctx context.Context, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelWarning) {
+		return
+	}
+	logCtx(logger, LevelWarning, ctx, args)
+}
+
+// WarningEvent returns an Event that will be written to the logger.stdLogger.warningWriter. It returns nil if LevelWarning is
+// disabled.
+func (logger *FastLogger) WarningEvent() *Event {
+	if !logger.stdLogger.isEnabled(LevelWarning) {
+		return nil
+	}
+	return newEvent(LevelWarning, logger)
 }
 
 // WarningPrepare logs a prepared record to the logger.warningWriter. Will not reset the record.
 func (logger *FastLogger) WarningPrepare(record *Record) {
-	logger.warningMutex.Lock()
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.stdLogger.isEnabled(LevelWarning) {
+		return
 	}
-	logger.warningLogs = append(logger.warningLogs, record.rec...)
-	logger.warningMutex.Unlock()
+	logger.logRecord(LevelWarning, record)
 }
 
 // Success logs a message to the logger.stdLogger.successWriter.
 func (logger *FastLogger) Success(args ...interface{}) {
-	logger.successMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.successLogs = append(logger.successLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelSuccess) {
+		return
 	}
-	logger.successLogs = addArgsToLog(logger.successLogs, args...)
-	logger.successLogs = append(logger.successLogs, '\n')
-	logger.successMutex.Unlock()
+	logArgs(logger, LevelSuccess, args)
 }
 
 // FormatSuccess logs a message with format to the logger.stdLogger.successWriter.
 func (logger *FastLogger) FormatSuccess(f string, args ...interface{}) {
-	logger.successMutex.Lock()
-	if logger.stdLogger.showDate {
-		logger.successLogs = append(logger.successLogs, Now.Load().([]byte)...)
+	if !logger.stdLogger.isEnabled(LevelSuccess) {
+		return
+	}
+	logFormat(logger, LevelSuccess, f, args)
+}
+
+// SuccessKV logs a message with fields to the logger.stdLogger.successWriter. The fields are given as alternating keys
+// and values.
+func (logger *FastLogger) SuccessKV(msg string, keysAndValues ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelSuccess) {
+		return
+	}
+	logKV(logger, LevelSuccess, msg, keysAndValues)
+}
+
+// SuccessCtx logs a message with the fields of the registered context keys to the logger.stdLogger.successWriter.
+func (logger *FastLogger) SuccessCtx(ctx context.Context, args ...interface{}) {
+	if !logger.stdLogger.isEnabled(LevelSuccess) {
+		return
+	}
+	logCtx(logger, LevelSuccess, ctx, args)
+}
+
+// SuccessEvent returns an Event that will be written to the logger.stdLogger.successWriter. It returns nil if LevelSuccess is
+// disabled.
+func (logger *FastLogger) SuccessEvent() *Event {
+	if !logger.stdLogger.isEnabled(LevelSuccess) {
+		return nil
 	}
-	logger.successLogs = append(logger.successLogs, fmt.Sprintf(f, args...)...)
-	logger.successMutex.Unlock()
+	return newEvent(LevelSuccess, logger)
 }
 
 // SuccessPrepare logs a prepared record to the logger.successWriter. Will not reset the record.
 func (logger *FastLogger) SuccessPrepare(record *Record) {
-	logger.successMutex.Lock()
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.stdLogger.isEnabled(LevelSuccess) {
+		return
 	}
-	logger.successLogs = append(logger.successLogs, record.rec...)
-	logger.successMutex.Unlock()
+	logger.logRecord(LevelSuccess, record)
 }
 
 // Fatal logs a message to the logger.stdLogger.fatalWriter.
@@ -345,6 +664,24 @@ func (logger *FastLogger) FormatFatal(f string, args ...interface{}) {
 	logger.stdLogger.FormatFatal(f, args...)
 }
 
+// FatalKV logs a message with fields to the logger.stdLogger.fatalWriter.
+func (logger *FastLogger) FatalKV(msg string, keysAndValues ...interface{}) {
+	logger.Flush()
+	logger.stdLogger.FatalKV(msg, keysAndValues...)
+}
+
+// FatalCtx logs a message with the fields of the registered context keys to the logger.stdLogger.fatalWriter.
+func (logger *FastLogger) FatalCtx(ctx context.Context, args ...interface{}) {
+	logger.Flush()
+	logger.stdLogger.FatalCtx(ctx, args...)
+}
+
+// FatalEvent returns an Event that will be written to the logger.stdLogger.fatalWriter. The program exits when the
+// event is written.
+func (logger *FastLogger) FatalEvent() *Event {
+	return newEvent(LevelFatal, logger)
+}
+
 // FatalPrepare logs a prepared record to the logger.fatalWriter. Will not reset the record.
 func (logger *FastLogger) FatalPrepare(record *Record) {
 	logger.Flush()
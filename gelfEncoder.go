@@ -0,0 +1,143 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GELFEncoder encodes entries into GELF 1.1 messages for Graylog, one per line:
+//
+//	{"version":"1.1","host":"api-1","short_message":"order created","timestamp":1696161600.123456,"level":6,"_id_":42}
+//
+// The first line of the message is the short_message, and a message of several lines is also written as
+// the full_message. A log without a message has the name of its level as the short_message. The timestamp is
+// the Unix time in seconds with microseconds. The level is the syslog severity of the level: 7 for trace and debug
+// messages, 6 for information and records, 5 for successes, 4 for warnings, 3 for errors and 2 for fatal errors.
+// The caller and the fields are additional fields with the '_' prefix. Characters other than letters, digits, '_', '.'
+// and '-' in their names are replaced with '_', and the "id" field, which is reserved by GELF, is written as "_id_".
+// Booleans and values of unknown types are written as strings, durations as numbers of milliseconds and times in
+// RFC 3339 format.
+//
+// Use a GELFWriter to send the messages to Graylog.
+type GELFEncoder struct {
+	// header is the beginning of every message with the version and the host.
+	header []byte
+}
+
+// NewGELFEncoder creates a new GELFEncoder. The host is the name of the host in the messages. If it is empty,
+// the name of the host reported by the kernel is used.
+func NewGELFEncoder(host string) *GELFEncoder {
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+	header := append([]byte(nil), `{"version":"1.1","host":"`...)
+	header = appendJSONString(header, host)
+	header = append(header, `","short_message":"`...)
+	return &GELFEncoder{header: header}
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *GELFEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	dst = append(dst, enc.header...)
+	prefix, msg := fastbytes.B2S(entry.Prefix), fastbytes.B2S(entry.Message)
+	newLine := strings.IndexByte(msg, '\n')
+	switch {
+	case newLine >= 0:
+		dst = appendJSONString(dst, prefix)
+		dst = appendJSONString(dst, msg[:newLine])
+	case len(prefix) == 0 && len(msg) == 0:
+		// short_message is required
+		dst = append(dst, entry.Level.String()...)
+	default:
+		dst = appendJSONString(dst, prefix)
+		dst = appendJSONString(dst, msg)
+	}
+	dst = append(dst, '"')
+	if newLine >= 0 {
+		dst = append(dst, `,"full_message":"`...)
+		dst = appendJSONString(dst, prefix)
+		dst = appendJSONString(dst, msg)
+		dst = append(dst, '"')
+	}
+	if entry.Date != nil {
+		dst = append(dst, `,"timestamp":`...)
+		dst = strconv.AppendFloat(dst, float64(entry.Time.UnixNano())/1e9, 'f', 6, 64)
+	}
+	dst = append(dst, `,"level":`...)
+	dst = strconv.AppendInt(dst, int64(entry.Level.syslogSeverity()), 10)
+	if len(entry.Caller) > 0 {
+		dst = enc.AppendString(dst, "caller", entry.Caller)
+	}
+	dst = append(dst, entry.Context...)
+	dst = append(dst, entry.Fields...)
+	return append(dst, '}', '\n')
+}
+
+func (enc *GELFEncoder) appendKey(dst []byte, key string) []byte {
+	dst = append(dst, ',', '"', '_')
+	for i := 0; i < len(key); i++ {
+		c := key[i]
+		if (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '_' || c == '.' || c == '-' {
+			dst = append(dst, c)
+		} else {
+			dst = append(dst, '_')
+		}
+	}
+	if key == "id" {
+		dst = append(dst, '_')
+	}
+	return append(dst, '"', ':')
+}
+
+func (enc *GELFEncoder) AppendString(dst []byte, key, value string) []byte {
+	dst = append(enc.appendKey(dst, key), '"')
+	dst = appendJSONString(dst, value)
+	return append(dst, '"')
+}
+
+func (enc *GELFEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	return strconv.AppendInt(enc.appendKey(dst, key), value, 10)
+}
+
+func (enc *GELFEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	return strconv.AppendUint(enc.appendKey(dst, key), value, 10)
+}
+
+// AppendFloat appends the float. NaN and infinities, which JSON can't represent, are written as strings.
+func (enc *GELFEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return enc.AppendString(dst, key, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	return strconv.AppendFloat(enc.appendKey(dst, key), value, 'f', -1, 64)
+}
+
+// AppendBool appends the bool as a string, as GELF has no booleans.
+func (enc *GELFEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	return enc.AppendString(dst, key, strconv.FormatBool(value))
+}
+
+// AppendDuration appends the duration as a number of milliseconds.
+func (enc *GELFEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	return strconv.AppendFloat(enc.appendKey(dst, key), float64(value)/float64(time.Millisecond), 'f', -1, 64)
+}
+
+// AppendTime appends the time as a string in RFC 3339 format.
+func (enc *GELFEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	dst = append(enc.appendKey(dst, key), '"')
+	dst = appendTime(dst, value)
+	return append(dst, '"')
+}
+
+func (enc *GELFEncoder) AppendError(dst []byte, key string, err error) []byte {
+	return enc.AppendString(dst, key, err.Error())
+}
+
+// AppendAny appends the value as a string formatted by fmt.
+func (enc *GELFEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	return enc.AppendString(dst, key, fmt.Sprint(value))
+}
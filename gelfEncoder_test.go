@@ -0,0 +1,51 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"github.com/Eugene-Usachev/logger"
+	"testing"
+	"time"
+)
+
+func TestGELFEncoderEntry(t *testing.T) {
+	enc := logger.NewGELFEncoder("api-1")
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.UTC)
+	tests := []struct {
+		entry logger.Entry
+		want  string
+	}{
+		{
+			logger.Entry{Time: at, Date: []byte("2023/10/01 12:00:00"), Level: logger.LevelInfo, Message: []byte("started")},
+			`{"version":"1.1","host":"api-1","short_message":"started","timestamp":1696161600.123457,"level":6}`,
+		},
+		{
+			logger.Entry{Time: at.Truncate(time.Second), Date: []byte("2023/10/01 12:00:00"), Level: logger.LevelError},
+			`{"version":"1.1","host":"api-1","short_message":"error","timestamp":1696161600.000000,"level":3}`,
+		},
+		{
+			logger.Entry{Level: logger.LevelWarning, Prefix: []byte("[db] "), Message: []byte("slow\nSELECT 1")},
+			`{"version":"1.1","host":"api-1","short_message":"[db] slow","full_message":"[db] slow\nSELECT 1",` +
+				`"level":4}`,
+		},
+		{
+			logger.Entry{
+				Level:   logger.LevelDebug,
+				Caller:  "server/handler.go:42",
+				Message: []byte("ping"),
+				Context: enc.AppendString(nil, "id", "7"),
+				Fields:  enc.AppendBool(enc.AppendInt(nil, "user name", 1), "ok", true),
+			},
+			`{"version":"1.1","host":"api-1","short_message":"ping","level":7,"_caller":"server/handler.go:42",` +
+				`"_id_":"7","_user_name":1,"_ok":"true"}`,
+		},
+	}
+	for _, test := range tests {
+		got := enc.AppendEntry(nil, test.entry)
+		if string(got) != test.want+"\n" {
+			t.Errorf("AppendEntry() = %s, want %s", got, test.want)
+		}
+		if !json.Valid(got) {
+			t.Errorf("AppendEntry() = %s, want valid JSON", got)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"fmt"
+	"net"
+	"sync"
+)
+
+const (
+	// defaultGELFChunkSize is the default maximum size of a UDP datagram. It fits into the MTU of most networks.
+	defaultGELFChunkSize = 1420
+	// gelfChunkHeaderSize is the size of the header of a chunk: the magic bytes, the id of the message,
+	// the sequence number and the count of the chunks.
+	gelfChunkHeaderSize = 12
+	// maxGELFChunks is the maximum count of the chunks of a message.
+	maxGELFChunks = 128
+)
+
+// GELFWriterConfig is the configuration of a GELFWriter.
+type GELFWriterConfig struct {
+	// Network is "udp" or "tcp". By default, it's "udp".
+	Network string
+	// Address is the address of the GELF input of Graylog, like "graylog:12201".
+	Address string
+	// ChunkSize is the maximum size of a UDP datagram. Larger messages are chunked. By default, it's 1420.
+	ChunkSize int
+	// Compress indicates whether UDP messages are compressed with gzip. GELF TCP inputs don't support compression,
+	// so it's ignored for TCP.
+	Compress bool
+}
+
+// GELFWriter is an io.Writer that sends the lines written by a GELFEncoder to Graylog over UDP, chunking large
+// messages, or over TCP, delimiting the messages with null bytes. Every line is a message, so it can receive
+// the flushed buffers of a FastLogger. A TCP connection is redialed once if a write fails.
+//
+// Example:
+//
+//	gelfWriter, err := logger.NewGELFWriter(&logger.GELFWriterConfig{Address: "graylog:12201"})
+//	if err != nil {
+//		panic(err)
+//	}
+//	log := logger.NewFastLogger(&logger.FastLoggerConfig{
+//		StandardLoggerConfig: logger.StandardLoggerConfig{
+//			UnifiedWriter: gelfWriter,
+//			ShowDate:      true,
+//			Encoder:       logger.NewGELFEncoder(""),
+//		},
+//		FlushInterval: time.Second,
+//	})
+type GELFWriter struct {
+	network   string
+	address   string
+	chunkSize int
+	compress  bool
+
+	mutex sync.Mutex
+	conn  net.Conn
+	// buf is the buffer of a message with the null byte, a compressed message or a chunk.
+	buf     []byte
+	gzipBuf bytes.Buffer
+	gzip    *gzip.Writer
+}
+
+// NewGELFWriter creates a new GELFWriter and dials Graylog.
+func NewGELFWriter(cfg *GELFWriterConfig) (*GELFWriter, error) {
+	w := &GELFWriter{
+		network:   cfg.Network,
+		address:   cfg.Address,
+		chunkSize: cfg.ChunkSize,
+		compress:  cfg.Compress,
+	}
+	if w.network == "" {
+		w.network = "udp"
+	}
+	if w.network != "udp" && w.network != "tcp" {
+		return nil, fmt.Errorf("logger: unsupported GELF network %q", w.network)
+	}
+	if w.chunkSize == 0 {
+		w.chunkSize = defaultGELFChunkSize
+	}
+	if w.chunkSize <= gelfChunkHeaderSize {
+		return nil, fmt.Errorf("logger: the GELF chunk size %d is too small", w.chunkSize)
+	}
+	if w.compress && w.network == "udp" {
+		w.gzip = gzip.NewWriter(&w.gzipBuf)
+	}
+	conn, err := net.Dial(w.network, w.address)
+	if err != nil {
+		return nil, err
+	}
+	w.conn = conn
+	return w, nil
+}
+
+// Write sends every line of p as a message. It returns the first error, after trying to send all lines.
+func (w *GELFWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var firstErr error
+	for rest := p; len(rest) > 0; {
+		msg := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			msg, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = nil
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		var err error
+		if w.network == "tcp" {
+			err = w.sendTCP(msg)
+		} else {
+			err = w.sendUDP(msg)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	return len(p), nil
+}
+
+// Close closes the connection.
+func (w *GELFWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.conn.Close()
+}
+
+func (w *GELFWriter) sendTCP(msg []byte) error {
+	w.buf = append(append(w.buf[:0], msg...), 0)
+	if _, err := w.conn.Write(w.buf); err == nil {
+		return nil
+	}
+	w.conn.Close()
+	conn, err := net.Dial(w.network, w.address)
+	if err != nil {
+		return err
+	}
+	w.conn = conn
+	_, err = w.conn.Write(w.buf)
+	return err
+}
+
+func (w *GELFWriter) sendUDP(msg []byte) error {
+	if w.gzip != nil {
+		w.gzipBuf.Reset()
+		w.gzip.Reset(&w.gzipBuf)
+		w.gzip.Write(msg)
+		if err := w.gzip.Close(); err != nil {
+			return err
+		}
+		msg = w.gzipBuf.Bytes()
+	}
+	if len(msg) <= w.chunkSize {
+		_, err := w.conn.Write(msg)
+		return err
+	}
+
+	dataSize := w.chunkSize - gelfChunkHeaderSize
+	count := (len(msg) + dataSize - 1) / dataSize
+	if count > maxGELFChunks {
+		return fmt.Errorf("logger: a GELF message of %d bytes needs more than %d chunks", len(msg), maxGELFChunks)
+	}
+	var id [8]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return fmt.Errorf("logger: can't generate the id of a GELF message: %w", err)
+	}
+	for seq := 0; seq < count; seq++ {
+		data := msg[seq*dataSize:]
+		if len(data) > dataSize {
+			data = data[:dataSize]
+		}
+		w.buf = append(w.buf[:0], 0x1e, 0x0f)
+		w.buf = append(w.buf, id[:]...)
+		w.buf = append(w.buf, byte(seq), byte(count))
+		w.buf = append(w.buf, data...)
+		if _, err := w.conn.Write(w.buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package logger_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/Eugene-Usachev/logger"
+	"io"
+	"net"
+	"testing"
+	"time"
+)
+
+func listenGELFUDP(t *testing.T) net.PacketConn {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func readDatagram(t *testing.T, conn net.PacketConn) []byte {
+	t.Helper()
+	buf := make([]byte, 65536)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("ReadFrom() error = %v", err)
+	}
+	return buf[:n]
+}
+
+func TestGELFWriterUDP(t *testing.T) {
+	conn := listenGELFUDP(t)
+	w, err := logger.NewGELFWriter(&logger.GELFWriterConfig{Address: conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatalf("NewGELFWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	log := logger.NewStandardLogger(&logger.StandardLoggerConfig{
+		UnifiedWriter: w,
+		Encoder:       logger.NewGELFEncoder("api-1"),
+	})
+	log.InfoKV("order created", "id", 42)
+
+	var msg map[string]interface{}
+	if err = json.Unmarshal(readDatagram(t, conn), &msg); err != nil {
+		t.Fatalf("the datagram is not a JSON message: %v", err)
+	}
+	want := map[string]interface{}{
+		"version":       "1.1",
+		"host":          "api-1",
+		"short_message": "order created",
+		"level":         float64(6),
+		"_id_":          float64(42),
+	}
+	for key, value := range want {
+		if msg[key] != value {
+			t.Errorf("%s = %v, want %v", key, msg[key], value)
+		}
+	}
+}
+
+func TestGELFWriterUDPChunkedGzip(t *testing.T) {
+	conn := listenGELFUDP(t)
+	const chunkSize = 200
+	w, err := logger.NewGELFWriter(&logger.GELFWriterConfig{
+		Address:   conn.LocalAddr().String(),
+		ChunkSize: chunkSize,
+		Compress:  true,
+	})
+	if err != nil {
+		t.Fatalf("NewGELFWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	random := make([]byte, 1000)
+	rand.Read(random)
+	msg := []byte(`{"version":"1.1","host":"api-1","short_message":"` + hex.EncodeToString(random) + `"}`)
+	if _, err = w.Write(append(msg, '\n')); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var compressed []byte
+	var id []byte
+	count := -1
+	for seq := 0; seq != count; seq++ {
+		chunk := readDatagram(t, conn)
+		if len(chunk) > chunkSize {
+			t.Fatalf("chunk %d has %d bytes, want at most %d", seq, len(chunk), chunkSize)
+		}
+		if chunk[0] != 0x1e || chunk[1] != 0x0f {
+			t.Fatalf("chunk %d starts with %#x %#x, want the magic bytes 0x1e 0x0f", seq, chunk[0], chunk[1])
+		}
+		if id == nil {
+			id = chunk[2:10]
+			count = int(chunk[11])
+			if count < 2 {
+				t.Fatalf("the message has %d chunks, want at least 2", count)
+			}
+		}
+		if !bytes.Equal(chunk[2:10], id) {
+			t.Errorf("chunk %d has the id %x, want %x", seq, chunk[2:10], id)
+		}
+		if int(chunk[10]) != seq || int(chunk[11]) != count {
+			t.Fatalf("chunk %d has the sequence number %d of %d, want %d of %d", seq, chunk[10], chunk[11], seq, count)
+		}
+		compressed = append(compressed, chunk[12:]...)
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("the message is not gzipped: %v", err)
+	}
+	got, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("can't decompress the message: %v", err)
+	}
+	if !bytes.Equal(got, msg) {
+		t.Errorf("message = %q, want %q", got, msg)
+	}
+}
+
+func TestGELFWriterTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			accepted <- conn
+		}
+	}()
+
+	w, err := logger.NewGELFWriter(&logger.GELFWriterConfig{
+		Network:  "tcp",
+		Address:  listener.Addr().String(),
+		Compress: true,
+	})
+	if err != nil {
+		t.Fatalf("NewGELFWriter() error = %v", err)
+	}
+	defer w.Close()
+	if _, err = w.Write([]byte("{\"short_message\":\"a\"}\n{\"short_message\":\"b\"}\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	var conn net.Conn
+	select {
+	case conn = <-accepted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the writer did not connect")
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(conn)
+	for _, want := range []string{`{"short_message":"a"}`, `{"short_message":"b"}`} {
+		got, err := reader.ReadString(0)
+		if err != nil {
+			t.Fatalf("ReadString() error = %v", err)
+		}
+		if got != want+"\x00" {
+			t.Errorf("message = %q, want %q followed by a null byte", got, want)
+		}
+	}
+}
@@ -1,14 +1,14 @@
 module github.com/Eugene-Usachev/logger
 
-go 1.20
+go 1.21
 
 require (
 	github.com/Eugene-Usachev/fastbytes v1.2.0
+	github.com/mattn/go-isatty v0.0.14
 	github.com/rs/zerolog v1.30.0
 )
 
 require (
 	github.com/mattn/go-colorable v0.1.12 // indirect
-	github.com/mattn/go-isatty v0.0.14 // indirect
 	golang.org/x/sys v0.0.0-20210927094055-39ccf1dd6fa6 // indirect
 )
@@ -3,7 +3,9 @@ package logger
 import (
 	"runtime"
 	"strconv"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 var Now = func() atomic.Value {
@@ -12,6 +14,85 @@ var Now = func() atomic.Value {
 	return v
 }()
 
+// timestamp is a cached time with its date.
+type timestamp struct {
+	time time.Time
+	// date is the time in the TimeFormatDefault or in the format of a logger.
+	date []byte
+}
+
+// clock is the cached current time. It is refreshed together with Now.
+var clock atomic.Pointer[timestamp]
+
+// defaultClockTick is the default interval between the refreshes of the clock.
+const defaultClockTick = 300 * time.Millisecond
+
+var (
+	// clockTick is the interval between the refreshes of the clock in nanoseconds, the shortest tick of the loggers.
+	clockTick atomic.Int64
+	clockOnce sync.Once
+)
+
+// startClock lowers the tick of the clock to the tick if it is shorter and starts the clock once. The clock is
+// refreshed before the first logger is returned, so the first logs have dates.
+func startClock(tick time.Duration) {
+	if tick <= 0 {
+		tick = defaultClockTick
+	}
+	for {
+		current := clockTick.Load()
+		if (current != 0 && current <= int64(tick)) || clockTick.CompareAndSwap(current, int64(tick)) {
+			break
+		}
+	}
+	clockOnce.Do(func() {
+		refreshClock()
+		go func() {
+			for {
+				time.Sleep(time.Duration(clockTick.Load()))
+				refreshClock()
+			}
+		}()
+	})
+}
+
+// refreshClock stores the current time into the clock and Now.
+func refreshClock() {
+	date := time.Now()
+	buf := make([]byte, 0, 19)
+	year, month, day := date.Date()
+	hour, min, sec := date.Clock()
+	buf = strconv.AppendInt(buf, int64(year), 10)
+	buf = append(buf, '/')
+	if month < 10 {
+		buf = append(buf, '0')
+	}
+	buf = strconv.AppendInt(buf, int64(month), 10)
+	buf = append(buf, '/')
+	if day < 10 {
+		buf = append(buf, '0')
+	}
+	buf = strconv.AppendInt(buf, int64(day), 10)
+	buf = append(buf, ' ')
+	if hour < 10 {
+		buf = append(buf, '0')
+	}
+	buf = strconv.AppendInt(buf, int64(hour), 10)
+	buf = append(buf, ':')
+	if min < 10 {
+		buf = append(buf, '0')
+	}
+	buf = strconv.AppendInt(buf, int64(min), 10)
+	buf = append(buf, ':')
+	if sec < 10 {
+		buf = append(buf, '0')
+	}
+	buf = strconv.AppendInt(buf, int64(sec), 10)
+	buf = append(buf, ' ')
+	Now.Store(buf)
+	clock.Store(&timestamp{time: date, date: buf[:len(buf)-1]})
+}
+
 func addArgsToLog(buf []byte, args ...interface{}) []byte {
 	for i := 0; i < len(args); i++ {
 		switch args[i].(type) {
@@ -82,3 +163,34 @@ func addArgsToLog(buf []byte, args ...interface{}) []byte {
 	}
 	return buf
 }
+
+// appendDuration appends the duration in milliseconds, like "1.5ms".
+func appendDuration(buf []byte, d time.Duration) []byte {
+	buf = strconv.AppendFloat(buf, float64(d)/float64(time.Millisecond), 'f', -1, 64)
+	return append(buf, "ms"...)
+}
+
+// appendTime appends the time in RFC 3339 format.
+func appendTime(buf []byte, t time.Time) []byte {
+	return t.AppendFormat(buf, time.RFC3339)
+}
+
+// appendString appends the string to the buf, quoting it if needed.
+func appendString(buf []byte, s string) []byte {
+	if needsQuoting(s) {
+		return strconv.AppendQuote(buf, s)
+	}
+	return append(buf, s...)
+}
+
+func needsQuoting(s string) bool {
+	if len(s) == 0 {
+		return true
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] <= ' ' || s[i] == '=' || s[i] == '"' || s[i] == 0x7f {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONEncoder encodes entries into JSON objects, one per line:
+//
+//	{"time":"2023/10/01 12:00:00","level":"info","msg":"message","service":"billing","key":"value"}
+//
+// The message of a record is prefixed with the prefix of the record. Durations are written as numbers of
+// milliseconds and times in RFC 3339 format.
+type JSONEncoder struct{}
+
+// NewJSONEncoder creates a new JSONEncoder.
+func NewJSONEncoder() *JSONEncoder {
+	return &JSONEncoder{}
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *JSONEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	dst = append(dst, '{')
+	if entry.Date != nil {
+		dst = append(dst, `"time":"`...)
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Date))
+		dst = append(dst, `",`...)
+	}
+	dst = append(dst, `"level":"`...)
+	if entry.Label != nil {
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Label))
+	} else {
+		dst = append(dst, entry.Level.String()...)
+	}
+	dst = append(dst, '"')
+	if len(entry.Caller) > 0 {
+		dst = append(dst, `,"caller":"`...)
+		dst = appendJSONString(dst, entry.Caller)
+		dst = append(dst, '"')
+	}
+	if len(entry.Prefix) > 0 || len(entry.Message) > 0 {
+		dst = append(dst, `,"msg":"`...)
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Prefix))
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Message))
+		dst = append(dst, '"')
+	}
+	dst = append(dst, entry.Context...)
+	dst = append(dst, entry.Fields...)
+	return append(dst, '}', '\n')
+}
+
+func (enc *JSONEncoder) appendKey(dst []byte, key string) []byte {
+	dst = append(dst, ',', '"')
+	dst = appendJSONString(dst, key)
+	return append(dst, '"', ':')
+}
+
+func (enc *JSONEncoder) AppendString(dst []byte, key, value string) []byte {
+	dst = append(enc.appendKey(dst, key), '"')
+	dst = appendJSONString(dst, value)
+	return append(dst, '"')
+}
+
+func (enc *JSONEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	return strconv.AppendInt(enc.appendKey(dst, key), value, 10)
+}
+
+func (enc *JSONEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	return strconv.AppendUint(enc.appendKey(dst, key), value, 10)
+}
+
+// AppendFloat appends the float. NaN and infinities, which JSON can't represent, are written as strings.
+func (enc *JSONEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	dst = enc.appendKey(dst, key)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		dst = append(dst, '"')
+		dst = strconv.AppendFloat(dst, value, 'f', -1, 64)
+		return append(dst, '"')
+	}
+	return strconv.AppendFloat(dst, value, 'f', -1, 64)
+}
+
+func (enc *JSONEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	return strconv.AppendBool(enc.appendKey(dst, key), value)
+}
+
+// AppendDuration appends the duration as a number of milliseconds.
+func (enc *JSONEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	return strconv.AppendFloat(enc.appendKey(dst, key), float64(value)/float64(time.Millisecond), 'f', -1, 64)
+}
+
+// AppendTime appends the time as a string in RFC 3339 format.
+func (enc *JSONEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	dst = append(enc.appendKey(dst, key), '"')
+	dst = appendTime(dst, value)
+	return append(dst, '"')
+}
+
+func (enc *JSONEncoder) AppendError(dst []byte, key string, err error) []byte {
+	return enc.AppendString(dst, key, err.Error())
+}
+
+// AppendAny appends the value encoded by encoding/json. Values that can't be marshaled are written as strings
+// formatted by fmt.
+func (enc *JSONEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return enc.AppendString(dst, key, fmt.Sprint(value))
+	}
+	return append(enc.appendKey(dst, key), data...)
+}
+
+// appendJSONString appends the string escaped for a JSON string without the quotes. Invalid UTF-8 is replaced
+// with U+FFFD.
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	start := 0
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c >= utf8.RuneSelf {
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if r == utf8.RuneError && size == 1 {
+				dst = append(dst, s[start:i]...)
+				dst = append(dst, "\ufffd"...)
+				i += size
+				start = i
+				continue
+			}
+			if r == '\u2028' || r == '\u2029' {
+				dst = append(dst, s[start:i]...)
+				dst = append(dst, `\u202`...)
+				dst = append(dst, hex[r&0xf])
+				i += size
+				start = i
+				continue
+			}
+			i += size
+			continue
+		}
+		if c >= ' ' && c != '"' && c != '\\' {
+			i++
+			continue
+		}
+		dst = append(dst, s[start:i]...)
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		default:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+		}
+		i++
+		start = i
+	}
+	return append(dst, s[start:]...)
+}
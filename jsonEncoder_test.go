@@ -0,0 +1,84 @@
+package logger_test
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Eugene-Usachev/logger"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestJSONEncoderFields(t *testing.T) {
+	enc := logger.NewJSONEncoder()
+	tests := []struct {
+		fields []byte
+		want   string
+	}{
+		{enc.AppendString(nil, "user", "bob smith"), `,"user":"bob smith"`},
+		{enc.AppendString(nil, "quote", `say "hi" \ bye`), `,"quote":"say \"hi\" \\ bye"`},
+		{enc.AppendString(nil, "lines", "a\nb\r\tc\x01"), `,"lines":"a\nb\r\tc\u0001"`},
+		{enc.AppendString(nil, "utf8", "h\u00e9llo\xff\u2028"), `,"utf8":"h` + "\u00e9llo\ufffd" + `\u2028"`},
+		{enc.AppendString(nil, `k"ey`, "v"), `,"k\"ey":"v"`},
+		{enc.AppendInt(nil, "id", -42), `,"id":-42`},
+		{enc.AppendFloat(nil, "ratio", 0.5), `,"ratio":0.5`},
+		{enc.AppendFloat(nil, "nan", math.NaN()), `,"nan":"NaN"`},
+		{enc.AppendFloat(nil, "inf", math.Inf(1)), `,"inf":"+Inf"`},
+		{enc.AppendDuration(nil, "took", 1500*time.Microsecond), `,"took":1.5`},
+		{enc.AppendError(nil, "err", errors.New(`bad "input"`)), `,"err":"bad \"input\""`},
+		{enc.AppendAny(nil, "tags", []string{"a", "b"}), `,"tags":["a","b"]`},
+		{enc.AppendAny(nil, "nil", nil), `,"nil":null`},
+	}
+	for _, test := range tests {
+		if string(test.fields) != test.want {
+			t.Errorf("got %s, want %s", test.fields, test.want)
+		}
+	}
+}
+
+func TestJSONEncoderEntry(t *testing.T) {
+	enc := logger.NewJSONEncoder()
+	line := enc.AppendEntry(nil, logger.Entry{
+		Date:    []byte("2023/10/01 12:00:00"),
+		Level:   logger.LevelWarning,
+		Prefix:  []byte("[db] "),
+		Message: []byte("slow \"query\"\n"),
+		Context: enc.AppendString(nil, "service", "billing"),
+		Fields:  enc.AppendInt(nil, "took", 120),
+	})
+	want := `{"time":"2023/10/01 12:00:00","level":"warning","msg":"[db] slow \"query\"\n","service":"billing",` +
+		`"took":120}` + "\n"
+	if string(line) != want {
+		t.Errorf("AppendEntry() = %s, want %s", line, want)
+	}
+	if !json.Valid(line) {
+		t.Errorf("AppendEntry() = %s, want valid JSON", line)
+	}
+
+	line = enc.AppendEntry(nil, logger.Entry{Level: logger.LevelInfo})
+	if want = `{"level":"info"}` + "\n"; string(line) != want {
+		t.Errorf("AppendEntry() without a message = %s, want %s", line, want)
+	}
+}
+
+func TestFormatNewLine(t *testing.T) {
+	tests := []struct {
+		encoder logger.Encoder
+		format  string
+		want    string
+	}{
+		{logger.NewTextEncoder(), "paid %d", "paid 42"},
+		{logger.NewTextEncoder(), "paid %d\n", "paid 42\n"},
+		{logger.NewJSONEncoder(), "paid %d", `{"level":"info","msg":"paid 42"}` + "\n"},
+		{logger.NewJSONEncoder(), "paid %d\n", `{"level":"info","msg":"paid 42"}` + "\n"},
+	}
+	for _, test := range tests {
+		var w levelWriters
+		cfg := w.config()
+		cfg.Encoder = test.encoder
+		logger.NewStandardLogger(&cfg).FormatInfo(test.format, 42)
+		if got := w.info.String(); got != test.want {
+			t.Errorf("FormatInfo(%q) with %T = %q, want %q", test.format, test.encoder, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package logger
+
+import "strconv"
+
+// Level is the severity of a log. Levels are ordered: a logger with the minimum level set to LevelWarning
+// writes warnings, errors and fatal errors and drops everything else.
+type Level int32
+
+const (
+	// LevelTrace is the most verbose level. It is the zero value, so a logger logs everything by default.
+	LevelTrace Level = iota
+	// LevelDebug is the level of debug messages.
+	LevelDebug
+	// LevelInfo is the level of information messages.
+	LevelInfo
+	// LevelSuccess is the level of success messages.
+	LevelSuccess
+	// LevelWarning is the level of warnings.
+	LevelWarning
+	// LevelError is the level of errors.
+	LevelError
+	// LevelFatal is the level of fatal errors. Fatal logs are never dropped.
+	LevelFatal
+)
+
+// DefaultLevelLabels are upper-case labels of the levels. Set them as StandardLoggerConfig.LevelLabels to render
+// the labels into the text logs, or use your own, for example {LevelError: "ERR"}.
+var DefaultLevelLabels = map[Level]string{
+	LevelTrace:   "TRACE",
+	LevelDebug:   "DEBUG",
+	LevelInfo:    "INFO",
+	LevelSuccess: "SUCCESS",
+	LevelWarning: "WARN",
+	LevelError:   "ERROR",
+	LevelFatal:   "FATAL",
+}
+
+// String returns the lower-case name of the level.
+func (level Level) String() string {
+	switch level {
+	case LevelTrace:
+		return "trace"
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelSuccess:
+		return "success"
+	case LevelWarning:
+		return "warning"
+	case LevelError:
+		return "error"
+	case LevelFatal:
+		return "fatal"
+	case levelRecord:
+		return "record"
+	default:
+		return "level(" + strconv.Itoa(int(level)) + ")"
+	}
+}
+
+// syslogSeverity returns the syslog severity of the level (RFC 5424): critical for fatal errors, notice for successes
+// and debug for debug and trace messages. Records are informational.
+func (level Level) syslogSeverity() int {
+	switch level {
+	case LevelTrace, LevelDebug:
+		return 7
+	case LevelSuccess:
+		return 5
+	case LevelWarning:
+		return 4
+	case LevelError:
+		return 3
+	case LevelFatal:
+		return 2
+	default:
+		return 6
+	}
+}
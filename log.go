@@ -0,0 +1,110 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"strings"
+)
+
+// entryLogger is a logger that writes entries. StandardLogger and FastLogger implement it to share the code that
+// builds the messages and the fields of the logs.
+type entryLogger interface {
+	// isEnabled reports whether logs of the level should be written.
+	isEnabled(level Level) bool
+	// fieldEncoder returns the encoder of the fields of the logs.
+	fieldEncoder() Encoder
+	// logEntry logs the message and the fields, encoded by the fieldEncoder, with the level.
+	logEntry(level Level, msg, fields []byte)
+	// logFormatted logs the formatted message with the level without the newline the encoder ends the log with.
+	logFormatted(level Level, msg []byte)
+	// logRecord logs the built or prepared record with the level. It does not reset the record.
+	logRecord(level Level, record *Record)
+}
+
+// errorArgEncoder is an encoder that maps the errors among the args of the logs onto its schema.
+type errorArgEncoder interface {
+	isErrorArgNeeded() bool
+}
+
+// appendErrorArgs appends the errors among the args to dst as the "error" field if the encoder needs them. Several
+// errors are joined with errors.Join into one field, so the encoders that map it onto their schema, like ECS, write
+// their fields once. The errors are written into the message as well.
+func appendErrorArgs(enc Encoder, dst []byte, args []interface{}) []byte {
+	if e, ok := enc.(errorArgEncoder); !ok || !e.isErrorArgNeeded() {
+		return dst
+	}
+	var first error
+	var errs []error
+	for _, arg := range args {
+		err, ok := arg.(error)
+		if !ok || err == nil {
+			continue
+		}
+		if first == nil {
+			first = err
+			continue
+		}
+		if errs == nil {
+			errs = append(errs, first)
+		}
+		errs = append(errs, err)
+	}
+	if errs != nil {
+		first = errors.Join(errs...)
+	}
+	if first == nil {
+		return dst
+	}
+	if multi, ok := enc.(*multiEncoder); ok {
+		return multi.appendErrorArg(dst, "error", first)
+	}
+	return enc.AppendError(dst, "error", first)
+}
+
+// logArgs logs the args with the level.
+func logArgs(logger entryLogger, level Level, args []interface{}) {
+	msg := Builder()
+	msg.rec = addArgsToLog(msg.rec, args...)
+	fields := Builder()
+	fields.rec = appendErrorArgs(logger.fieldEncoder(), fields.rec, args)
+	logger.logEntry(level, msg.rec, fields.rec)
+	msg.release()
+	fields.release()
+}
+
+// logFormat logs the message with format with the level. The text encoder writes the message as is, so the log ends
+// with a newline only if the format does. Other encoders end every log with a newline, so a trailing newline of the
+// message is dropped for them.
+func logFormat(logger entryLogger, level Level, f string, args []interface{}) {
+	msg := fmt.Sprintf(f, args...)
+	if _, ok := logger.fieldEncoder().(*TextEncoder); ok {
+		logger.logFormatted(level, fastbytes.S2B(msg))
+		return
+	}
+	fields := Builder()
+	fields.rec = appendErrorArgs(logger.fieldEncoder(), fields.rec, args)
+	logger.logEntry(level, fastbytes.S2B(strings.TrimSuffix(msg, "\n")), fields.rec)
+	fields.release()
+}
+
+// logKV logs the message and the fields with the level.
+func logKV(logger entryLogger, level Level, msg string, keysAndValues []interface{}) {
+	fields := Builder()
+	fields.rec = appendKeyValues(logger.fieldEncoder(), fields.rec, keysAndValues)
+	logger.logEntry(level, fastbytes.S2B(msg), fields.rec)
+	fields.release()
+}
+
+// logCtx logs the args and the fields of the registered context keys with the level.
+func logCtx(logger entryLogger, level Level, ctx context.Context, args []interface{}) {
+	msg := Builder()
+	msg.rec = addArgsToLog(msg.rec, args...)
+	fields := Builder()
+	fields.rec = appendContextFields(logger.fieldEncoder(), fields.rec, ctx)
+	fields.rec = appendErrorArgs(logger.fieldEncoder(), fields.rec, args)
+	logger.logEntry(level, msg.rec, fields.rec)
+	msg.release()
+	fields.release()
+}
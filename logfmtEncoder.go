@@ -0,0 +1,54 @@
+package logger
+
+import "github.com/Eugene-Usachev/fastbytes"
+
+// LogfmtEncoder encodes entries into logfmt lines:
+//
+//	ts="2023/10/01 12:00:00" level=info msg="order created" service=billing id=42
+//
+// Values are quoted if they contain spaces, quotes, '=' or control characters. Spaces, quotes, '=' and control
+// characters in keys are replaced with '_'. The level is written as its label if the logger has labels. The message
+// of a record is prefixed with the prefix of the record. Fields are encoded like by TextEncoder.
+type LogfmtEncoder struct {
+	TextEncoder
+}
+
+// NewLogfmtEncoder creates a new LogfmtEncoder.
+func NewLogfmtEncoder() *LogfmtEncoder {
+	return &LogfmtEncoder{}
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *LogfmtEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	if entry.Date != nil {
+		dst = append(dst, "ts="...)
+		dst = appendString(dst, fastbytes.B2S(entry.Date))
+		dst = append(dst, ' ')
+	}
+	dst = append(dst, "level="...)
+	if entry.Label != nil {
+		dst = appendString(dst, fastbytes.B2S(entry.Label))
+	} else {
+		dst = append(dst, entry.Level.String()...)
+	}
+	if len(entry.Caller) > 0 {
+		dst = append(dst, " caller="...)
+		dst = appendString(dst, entry.Caller)
+	}
+	if len(entry.Prefix) > 0 || len(entry.Message) > 0 {
+		dst = append(dst, " msg="...)
+		prefix, msg := fastbytes.B2S(entry.Prefix), fastbytes.B2S(entry.Message)
+		if (len(prefix) > 0 && needsQuoting(prefix)) || (len(msg) > 0 && needsQuoting(msg)) {
+			dst = append(dst, '"')
+			dst = appendJSONString(dst, prefix)
+			dst = appendJSONString(dst, msg)
+			dst = append(dst, '"')
+		} else {
+			dst = append(dst, prefix...)
+			dst = append(dst, msg...)
+		}
+	}
+	dst = append(dst, entry.Context...)
+	dst = append(dst, entry.Fields...)
+	return append(dst, '\n')
+}
@@ -0,0 +1,69 @@
+package logger_test
+
+import (
+	"github.com/Eugene-Usachev/logger"
+	"testing"
+)
+
+func TestLogfmtEncoderEntry(t *testing.T) {
+	enc := logger.NewLogfmtEncoder()
+	tests := []struct {
+		entry logger.Entry
+		want  string
+	}{
+		{
+			logger.Entry{Level: logger.LevelInfo, Message: []byte("started")},
+			"level=info msg=started\n",
+		},
+		{
+			logger.Entry{
+				Date:    []byte("2023/10/01 12:00:00"),
+				Level:   logger.LevelWarning,
+				Message: []byte("slow query"),
+				Context: enc.AppendString(nil, "service", "billing"),
+				Fields:  enc.AppendInt(nil, "took", 120),
+			},
+			`ts="2023/10/01 12:00:00" level=warning msg="slow query" service=billing took=120` + "\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelError, Prefix: []byte("[db] "), Message: []byte(`say "hi"` + "\n")},
+			`level=error msg="[db] say \"hi\"\n"` + "\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelDebug, Prefix: []byte("[db]"), Message: []byte("ping")},
+			"level=debug msg=[db]ping\n",
+		},
+		{
+			logger.Entry{Level: logger.LevelInfo, Fields: enc.AppendBool(nil, "ok", true)},
+			"level=info ok=true\n",
+		},
+	}
+	for _, test := range tests {
+		if got := string(enc.AppendEntry(nil, test.entry)); got != test.want {
+			t.Errorf("AppendEntry() = %q, want %q", got, test.want)
+		}
+	}
+}
+
+func TestLogfmtEncoderFields(t *testing.T) {
+	enc := logger.NewLogfmtEncoder()
+	tests := []struct {
+		fields []byte
+		want   string
+	}{
+		{enc.AppendString(nil, "user", "bob"), " user=bob"},
+		{enc.AppendString(nil, "user", "bob smith"), ` user="bob smith"`},
+		{enc.AppendString(nil, "query", "a=b"), ` query="a=b"`},
+		{enc.AppendString(nil, "quote", `say "hi"`), ` quote="say \"hi\""`},
+		{enc.AppendString(nil, "line", "a\nb"), ` line="a\nb"`},
+		{enc.AppendString(nil, "empty", ""), ` empty=""`},
+		{enc.AppendString(nil, "user name", "bob"), " user_name=bob"},
+		{enc.AppendString(nil, `a="b"`, "c"), " a__b_=c"},
+		{enc.AppendString(nil, "tab\tkey\x7f", "c"), " tab_key_=c"},
+	}
+	for _, test := range tests {
+		if string(test.fields) != test.want {
+			t.Errorf("got %q, want %q", test.fields, test.want)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"os"
+)
+
+// Logger is the method set shared by StandardLogger and FastLogger. Accept a Logger in libraries and let the application
+// decide whether the logs are written synchronously or buffered.
+type Logger interface {
+	// SetLevel sets the minimum level of logs to be written.
+	SetLevel(level Level)
+	// Level returns the minimum level of logs to be written.
+	Level() Level
+
+	// Record logs a record. You can create a record with Builder().
+	Record(record *Record)
+	// Raw logs a raw log.
+	Raw(data []byte)
+	// Writer returns an io.Writer that logs every written line with the level.
+	Writer(level Level) io.Writer
+
+	Trace(args ...interface{})
+	FormatTrace(f string, args ...interface{})
+	TraceKV(msg string, keysAndValues ...interface{})
+	TraceCtx(ctx context.Context, args ...interface{})
+	TraceEvent() *Event
+	TracePrepare(record *Record)
+
+	Debug(args ...interface{})
+	FormatDebug(f string, args ...interface{})
+	DebugKV(msg string, keysAndValues ...interface{})
+	DebugCtx(ctx context.Context, args ...interface{})
+	DebugEvent() *Event
+	DebugPrepare(record *Record)
+
+	Info(args ...interface{})
+	FormatInfo(f string, args ...interface{})
+	InfoKV(msg string, keysAndValues ...interface{})
+	InfoCtx(ctx context.Context, args ...interface{})
+	InfoEvent() *Event
+	InfoPrepare(record *Record)
+
+	Success(args ...interface{})
+	FormatSuccess(f string, args ...interface{})
+	SuccessKV(msg string, keysAndValues ...interface{})
+	SuccessCtx(ctx context.Context, args ...interface{})
+	SuccessEvent() *Event
+	SuccessPrepare(record *Record)
+
+	Warning(args ...interface{})
+	FormatWarning(f string, args ...interface{})
+	WarningKV(msg string, keysAndValues ...interface{})
+	WarningCtx(ctx context.Context, args ...interface{})
+	WarningEvent() *Event
+	WarningPrepare(record *Record)
+
+	Error(args ...interface{})
+	FormatError(f string, args ...interface{})
+	ErrorKV(msg string, keysAndValues ...interface{})
+	ErrorCtx(ctx context.Context, args ...interface{})
+	ErrorEvent() *Event
+	ErrorPrepare(record *Record)
+
+	// Fatal, FormatFatal, FatalKV, FatalCtx, FatalPrepare and the events of FatalEvent exit the program after logging.
+	Fatal(args ...interface{})
+	FormatFatal(f string, args ...interface{})
+	FatalKV(msg string, keysAndValues ...interface{})
+	FatalCtx(ctx context.Context, args ...interface{})
+	FatalEvent() *Event
+	FatalPrepare(record *Record)
+}
+
+var (
+	_ Logger = (*StandardLogger)(nil)
+	_ Logger = (*FastLogger)(nil)
+	_ Logger = NopLogger{}
+)
+
+// NopLogger is a Logger that writes nothing. Fatal methods still exit the program, as callers expect them not to return.
+type NopLogger struct{}
+
+func (NopLogger) SetLevel(Level)                             {}
+func (NopLogger) Level() Level                               { return LevelFatal }
+func (NopLogger) Record(*Record)                             {}
+func (NopLogger) Writer(Level) io.Writer                     { return io.Discard }
+func (NopLogger) Raw([]byte)                                 {}
+func (NopLogger) Trace(...interface{})                       {}
+func (NopLogger) FormatTrace(string, ...interface{})         {}
+func (NopLogger) TraceKV(string, ...interface{})             {}
+func (NopLogger) TraceCtx(context.Context, ...interface{})   {}
+func (NopLogger) TraceEvent() *Event                         { return nil }
+func (NopLogger) TracePrepare(*Record)                       {}
+func (NopLogger) Debug(...interface{})                       {}
+func (NopLogger) FormatDebug(string, ...interface{})         {}
+func (NopLogger) DebugKV(string, ...interface{})             {}
+func (NopLogger) DebugCtx(context.Context, ...interface{})   {}
+func (NopLogger) DebugEvent() *Event                         { return nil }
+func (NopLogger) DebugPrepare(*Record)                       {}
+func (NopLogger) Info(...interface{})                        {}
+func (NopLogger) FormatInfo(string, ...interface{})          {}
+func (NopLogger) InfoKV(string, ...interface{})              {}
+func (NopLogger) InfoCtx(context.Context, ...interface{})    {}
+func (NopLogger) InfoEvent() *Event                          { return nil }
+func (NopLogger) InfoPrepare(*Record)                        {}
+func (NopLogger) Success(...interface{})                     {}
+func (NopLogger) FormatSuccess(string, ...interface{})       {}
+func (NopLogger) SuccessKV(string, ...interface{})           {}
+func (NopLogger) SuccessCtx(context.Context, ...interface{}) {}
+func (NopLogger) SuccessEvent() *Event                       { return nil }
+func (NopLogger) SuccessPrepare(*Record)                     {}
+func (NopLogger) Warning(...interface{})                     {}
+func (NopLogger) FormatWarning(string, ...interface{})       {}
+func (NopLogger) WarningKV(string, ...interface{})           {}
+func (NopLogger) WarningCtx(context.Context, ...interface{}) {}
+func (NopLogger) WarningEvent() *Event                       { return nil }
+func (NopLogger) WarningPrepare(*Record)                     {}
+func (NopLogger) Error(...interface{})                       {}
+func (NopLogger) FormatError(string, ...interface{})         {}
+func (NopLogger) ErrorKV(string, ...interface{})             {}
+func (NopLogger) ErrorCtx(context.Context, ...interface{})   {}
+func (NopLogger) ErrorEvent() *Event                         { return nil }
+func (NopLogger) ErrorPrepare(*Record)                       {}
+func (NopLogger) Fatal(...interface{})                       { os.Exit(1) }
+func (NopLogger) FormatFatal(string, ...interface{})         { os.Exit(1) }
+func (NopLogger) FatalKV(string, ...interface{})             { os.Exit(1) }
+func (NopLogger) FatalCtx(context.Context, ...interface{})   { os.Exit(1) }
+func (l NopLogger) FatalEvent() *Event                       { return newEvent(LevelFatal, l) }
+func (NopLogger) FatalPrepare(*Record)                       { os.Exit(1) }
+
+func (NopLogger) isEnabled(level Level) bool { return level == LevelFatal }
+func (NopLogger) fieldEncoder() Encoder      { return nopEncoder }
+func (NopLogger) logRecord(Level, *Record)   {}
+func (NopLogger) logFormatted(level Level, _ []byte) {
+	if level == LevelFatal {
+		os.Exit(1)
+	}
+}
+func (NopLogger) logEntry(level Level, _, _ []byte) {
+	if level == LevelFatal {
+		os.Exit(1)
+	}
+}
+
+// nopEncoder encodes the fields of the fatal events of NopLogger.
+var nopEncoder = NewTextEncoder()
+
+// With returns a child of the logger that writes the fields to every log. See StandardLogger.With and FastLogger.With.
+// Loggers of other types are returned as is.
+func With(logger Logger, keysAndValues ...interface{}) Logger {
+	switch l := logger.(type) {
+	case *StandardLogger:
+		return l.With(keysAndValues...)
+	case *FastLogger:
+		return l.With(keysAndValues...)
+	default:
+		return logger
+	}
+}
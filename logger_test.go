@@ -0,0 +1,42 @@
+package logger_test
+
+import (
+	"github.com/Eugene-Usachev/logger"
+	"testing"
+	"time"
+)
+
+// logThroughInterface logs like a library that accepts a Logger.
+func logThroughInterface(log logger.Logger) {
+	log.SetLevel(logger.LevelInfo)
+	log.Debug("dropped")
+	log.Info("info")
+	log.FormatWarning("warning %d\n", 2)
+	log.ErrorPrepare(logger.Builder().NoDate().AppendArgs("error").Prepare())
+}
+
+func TestLoggerInterface(t *testing.T) {
+	want := map[string]string{"debug": "", "info": "info\n", "warning": "warning 2\n", "error": "error\n"}
+
+	var std levelWriters
+	cfg := std.config()
+	logThroughInterface(logger.NewStandardLogger(&cfg))
+	checkLevelWriters(t, &std, want)
+
+	var fast levelWriters
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: fast.config(), FlushInterval: time.Hour})
+	defer fastLogger.Stop()
+	logThroughInterface(fastLogger)
+	fastLogger.Flush()
+	checkLevelWriters(t, &fast, want)
+}
+
+func TestNopLogger(t *testing.T) {
+	var log logger.Logger = logger.NopLogger{}
+	logThroughInterface(log)
+	log.Record(logger.Builder().AppendArgs("record").Build())
+	log.Raw([]byte("raw\n"))
+	if log.Level() != logger.LevelFatal {
+		t.Errorf("Level() = %v, want fatal", log.Level())
+	}
+}
@@ -0,0 +1,251 @@
+// Package loggertest provides loggers for unit tests. The loggers write every log through testing.TB.Log and keep
+// the logs in memory, so tests can assert what was logged without parsing files or matching dates. The loggers
+// encode the logs with a JSON encoder, so the messages and the fields are recorded exactly.
+//
+// Example:
+//
+//	func TestCreateOrder(t *testing.T) {
+//		log := loggertest.NewTestFastLogger(t)
+//		createOrder(log.FastLogger, 42)
+//		log.AssertLogged(logger.LevelInfo, "order created")
+//	}
+package loggertest
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Eugene-Usachev/logger"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Entry is a logged line.
+type Entry struct {
+	Level logger.Level
+	// Message is the message of the log, including the prefix of a record.
+	Message string
+	// Fields are the fields of the log and of the logger, such as the fields of With and the KV methods. Strings are
+	// kept as is, other values are kept as JSON, like "42" or "true".
+	Fields map[string]string
+	// Line is the message followed by the fields as "key=value" pairs in the order they were written.
+	Line string
+}
+
+// Recorder keeps the entries written by a test logger.
+type Recorder struct {
+	t testing.TB
+	// flush writes buffered logs to the recorder before reading the entries. It is nil for StandardLogger.
+	flush func()
+
+	mutex   sync.Mutex
+	entries []Entry
+}
+
+func newRecorder(t testing.TB) *Recorder {
+	return &Recorder{t: t}
+}
+
+// config returns the config of a logger that writes every level to the recorder.
+func (r *Recorder) config() logger.StandardLoggerConfig {
+	return logger.StandardLoggerConfig{
+		TraceWriter:   &levelWriter{recorder: r, level: logger.LevelTrace},
+		DebugWriter:   &levelWriter{recorder: r, level: logger.LevelDebug},
+		InfoWriter:    &levelWriter{recorder: r, level: logger.LevelInfo},
+		SuccessWriter: &levelWriter{recorder: r, level: logger.LevelSuccess},
+		WarningWriter: &levelWriter{recorder: r, level: logger.LevelWarning},
+		ErrorWriter:   &levelWriter{recorder: r, level: logger.LevelError},
+		FatalWriter:   &levelWriter{recorder: r, level: logger.LevelFatal},
+		Encoder:       logger.NewJSONEncoder(),
+	}
+}
+
+// All returns all entries in the order they were written.
+func (r *Recorder) All() []Entry {
+	if r.flush != nil {
+		r.flush()
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Entry(nil), r.entries...)
+}
+
+// Entries returns the entries of the level in the order they were written.
+func (r *Recorder) Entries(level logger.Level) []Entry {
+	var entries []Entry
+	for _, entry := range r.All() {
+		if entry.Level == level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Reset forgets all entries.
+func (r *Recorder) Reset() {
+	if r.flush != nil {
+		r.flush()
+	}
+	r.mutex.Lock()
+	r.entries = r.entries[:0]
+	r.mutex.Unlock()
+}
+
+// AssertLogged fails the test if no entry of the level contains the substring.
+func (r *Recorder) AssertLogged(level logger.Level, substring string) {
+	r.t.Helper()
+	for _, entry := range r.Entries(level) {
+		if strings.Contains(entry.Line, substring) {
+			return
+		}
+	}
+	r.t.Errorf("loggertest: no %s entry contains %q", level, substring)
+}
+
+// AssertNotLogged fails the test if an entry of the level contains the substring.
+func (r *Recorder) AssertNotLogged(level logger.Level, substring string) {
+	r.t.Helper()
+	for _, entry := range r.Entries(level) {
+		if strings.Contains(entry.Line, substring) {
+			r.t.Errorf("loggertest: %s entry %q contains %q", level, entry.Line, substring)
+			return
+		}
+	}
+}
+
+// AssertField fails the test if no entry of the level has the field with the value.
+func (r *Recorder) AssertField(level logger.Level, key, value string) {
+	r.t.Helper()
+	for _, entry := range r.Entries(level) {
+		if v, ok := entry.Fields[key]; ok && v == value {
+			return
+		}
+	}
+	r.t.Errorf("loggertest: no %s entry has the field %s=%q", level, key, value)
+}
+
+// record records the line of the level and writes it through t.Log.
+func (r *Recorder) record(level logger.Level, line []byte) {
+	r.t.Helper()
+	entry, err := parseLine(line)
+	if err != nil {
+		r.t.Errorf("loggertest: can't parse %q: %v", line, err)
+		return
+	}
+	entry.Level = level
+	r.t.Log(level.String() + ": " + entry.Line)
+	r.mutex.Lock()
+	r.entries = append(r.entries, entry)
+	r.mutex.Unlock()
+}
+
+// levelWriter is the writer of a level of a test logger. It records every written line.
+type levelWriter struct {
+	recorder *Recorder
+	level    logger.Level
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	w.recorder.t.Helper()
+	for _, line := range bytes.Split(bytes.TrimSuffix(p, []byte{'\n'}), []byte{'\n'}) {
+		w.recorder.record(w.level, line)
+	}
+	return len(p), nil
+}
+
+// The stages of parseLine. The encoder writes the time and the level, then the message and then the fields.
+const (
+	beforeLevel = iota
+	afterLevel
+	inFields
+)
+
+// parseLine decodes the JSON line of the log. Only the "time", "level" and "msg" keys written before the fields are
+// the keys of the log, so fields with the same keys are recorded as fields.
+func parseLine(line []byte) (Entry, error) {
+	entry := Entry{Fields: map[string]string{}}
+	var fields []byte
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	if _, err := decoder.Token(); err != nil {
+		return entry, err
+	}
+	stage := beforeLevel
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return entry, err
+		}
+		key, _ := token.(string)
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			return entry, err
+		}
+		value := string(raw)
+		var s string
+		if json.Unmarshal(raw, &s) == nil {
+			value = s
+		}
+		switch {
+		case stage == beforeLevel && key == "time":
+		case stage == beforeLevel && key == "level":
+			stage = afterLevel
+		case stage == afterLevel && key == "msg":
+			entry.Message = value
+			stage = inFields
+		default:
+			stage = inFields
+			entry.Fields[key] = value
+			fields = lineEncoder.AppendString(fields, key, value)
+		}
+	}
+	entry.Line = string(lineEncoder.AppendEntry(nil, logger.Entry{Message: []byte(entry.Message), Fields: fields}))
+	entry.Line = strings.TrimSuffix(entry.Line, "\n")
+	return entry, nil
+}
+
+// lineEncoder encodes the lines of the entries.
+var lineEncoder = logger.NewTextEncoder()
+
+// TestLogger is a StandardLogger that records its logs.
+type TestLogger struct {
+	*logger.StandardLogger
+	*Recorder
+}
+
+// NewTestLogger creates a StandardLogger that writes every level through t.Log and records it. Records and raw logs
+// are not recorded. Fatal logs still exit the program.
+func NewTestLogger(t testing.TB) *TestLogger {
+	recorder := newRecorder(t)
+	cfg := recorder.config()
+	return &TestLogger{
+		StandardLogger: logger.NewStandardLogger(&cfg),
+		Recorder:       recorder,
+	}
+}
+
+// TestFastLogger is a FastLogger that records its logs. It is flushed before the entries are read and when the test
+// ends, so the entries do not depend on the flush interval.
+type TestFastLogger struct {
+	*logger.FastLogger
+	*Recorder
+}
+
+// NewTestFastLogger creates a FastLogger that writes every level through t.Log and records it. Records and raw logs
+// are not recorded. Fatal logs still exit the program.
+func NewTestFastLogger(t testing.TB) *TestFastLogger {
+	recorder := newRecorder(t)
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{
+		StandardLoggerConfig: recorder.config(),
+		FlushInterval:        time.Hour,
+	})
+	recorder.flush = fastLogger.Flush
+	t.Cleanup(func() {
+		fastLogger.Flush()
+		fastLogger.StopWithoutFlush()
+	})
+	return &TestFastLogger{
+		FastLogger: fastLogger,
+		Recorder:   recorder,
+	}
+}
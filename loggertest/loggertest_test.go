@@ -0,0 +1,124 @@
+package loggertest_test
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/logger"
+	"github.com/Eugene-Usachev/logger/loggertest"
+	"reflect"
+	"testing"
+)
+
+// fakeTB is a testing.TB that keeps the logs and the failures instead of reporting them.
+type fakeTB struct {
+	testing.TB
+	logs     []string
+	failures []string
+}
+
+func (tb *fakeTB) Helper() {}
+
+func (tb *fakeTB) Log(args ...interface{}) {
+	tb.logs = append(tb.logs, fmt.Sprint(args...))
+}
+
+func (tb *fakeTB) Errorf(format string, args ...interface{}) {
+	tb.failures = append(tb.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertLogged(t *testing.T) {
+	tests := []struct {
+		assert       func(log *loggertest.TestLogger)
+		wantFailures int
+	}{
+		{func(log *loggertest.TestLogger) { log.AssertLogged(logger.LevelInfo, "order created") }, 0},
+		{func(log *loggertest.TestLogger) { log.AssertLogged(logger.LevelInfo, "id=42") }, 0},
+		{func(log *loggertest.TestLogger) { log.AssertLogged(logger.LevelError, "order created") }, 1},
+		{func(log *loggertest.TestLogger) { log.AssertLogged(logger.LevelInfo, "payment") }, 1},
+		{func(log *loggertest.TestLogger) { log.AssertNotLogged(logger.LevelError, "") }, 0},
+		{func(log *loggertest.TestLogger) { log.AssertNotLogged(logger.LevelInfo, "created") }, 1},
+	}
+	for i, test := range tests {
+		tb := &fakeTB{TB: t}
+		log := loggertest.NewTestLogger(tb)
+		log.InfoKV("order created", "id", 42)
+		test.assert(log)
+		if len(tb.failures) != test.wantFailures {
+			t.Errorf("assertion %d failed %d times, want %d: %q", i, len(tb.failures), test.wantFailures, tb.failures)
+		}
+	}
+}
+
+func TestAssertField(t *testing.T) {
+	tests := []struct {
+		key, value   string
+		wantFailures int
+	}{
+		{"id", "42", 0},
+		{"user", "bob smith", 0},
+		{"service", "billing", 0},
+		{"msg", "user message", 0},
+		{"level", "user level", 0},
+		{"time", "user time", 0},
+		{"id", "43", 1},
+		{"missing", "", 1},
+	}
+	for _, test := range tests {
+		tb := &fakeTB{TB: t}
+		log := loggertest.NewTestLogger(tb)
+		log.With("service", "billing").InfoKV("order created", "id", 42, "user", "bob smith",
+			"msg", "user message", "level", "user level", "time", "user time")
+		log.AssertField(logger.LevelInfo, test.key, test.value)
+		if len(tb.failures) != test.wantFailures {
+			t.Errorf("AssertField(%q, %q) failed %d times, want %d", test.key, test.value, len(tb.failures), test.wantFailures)
+		}
+	}
+}
+
+func TestTestFastLogger(t *testing.T) {
+	tb := &fakeTB{TB: t}
+	log := loggertest.NewTestFastLogger(tb)
+	log.Info("first")
+	log.ErrorKV("payment failed", "id", 7)
+	log.Info("second")
+
+	var messages []string
+	for _, entry := range log.Entries(logger.LevelInfo) {
+		messages = append(messages, entry.Message)
+	}
+	if want := []string{"first", "second"}; !reflect.DeepEqual(messages, want) {
+		t.Errorf("the messages of the info entries = %q, want %q", messages, want)
+	}
+	errors := log.Entries(logger.LevelError)
+	if len(errors) != 1 || errors[0].Message != "payment failed" || errors[0].Fields["id"] != "7" {
+		t.Errorf("the error entries = %+v, want \"payment failed\" with id=7", errors)
+	}
+	if len(tb.logs) != 3 {
+		t.Errorf("t.Log was called %d times, want 3: %q", len(tb.logs), tb.logs)
+	}
+
+	log.Reset()
+	if entries := log.All(); len(entries) != 0 {
+		t.Errorf("All() after Reset() = %+v, want no entries", entries)
+	}
+}
+
+func TestReservedKeys(t *testing.T) {
+	log := loggertest.NewTestLogger(&fakeTB{TB: t})
+	log.InfoKV("order created", "msg", "user message", "level", "user level")
+	log.With("time", "user time").Info("")
+
+	entries := log.Entries(logger.LevelInfo)
+	if len(entries) != 2 {
+		t.Fatalf("the info entries = %+v, want 2 entries", entries)
+	}
+	if entries[0].Message != "order created" || entries[0].Fields["msg"] != "user message" ||
+		entries[0].Fields["level"] != "user level" {
+		t.Errorf("the first entry = %+v, want \"order created\" with the msg and level fields", entries[0])
+	}
+	if want := `order created msg="user message" level="user level"`; entries[0].Line != want {
+		t.Errorf("the line of the first entry = %q, want %q", entries[0].Line, want)
+	}
+	if entries[1].Message != "" || entries[1].Fields["time"] != "user time" {
+		t.Errorf("the second entry = %+v, want no message with the time field", entries[1])
+	}
+}
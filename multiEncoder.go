@@ -0,0 +1,159 @@
+package logger
+
+import (
+	"encoding/binary"
+	"time"
+)
+
+// multiEncoder encodes the fields of the logs of a logger with several encoders, like a console encoder and
+// the encoder of the writers. Every field is encoded by every encoder, once, and the fragments of the encoders are
+// stored one after another with their lengths as 4-byte big-endian integers. Loggers take the fragments of one
+// encoder with extractFields before encoding an entry with it.
+type multiEncoder struct {
+	encoders []Encoder
+}
+
+// AppendEntry appends the entry encoded by the first encoder. Loggers encode entries with the encoders themselves.
+func (enc *multiEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	entry.Context = extractFields(nil, entry.Context, 0, len(enc.encoders))
+	entry.Fields = extractFields(nil, entry.Fields, 0, len(enc.encoders))
+	return enc.encoders[0].AppendEntry(dst, entry)
+}
+
+// isCallerNeeded reports whether any of the encoders needs the callers of the logs.
+func (enc *multiEncoder) isCallerNeeded() bool {
+	for _, e := range enc.encoders {
+		if c, ok := e.(callerEncoder); ok && c.isCallerNeeded() {
+			return true
+		}
+	}
+	return false
+}
+
+// isErrorArgNeeded reports whether any of the encoders needs the errors among the args.
+func (enc *multiEncoder) isErrorArgNeeded() bool {
+	for _, e := range enc.encoders {
+		if c, ok := e.(errorArgEncoder); ok && c.isErrorArgNeeded() {
+			return true
+		}
+	}
+	return false
+}
+
+// reserveFragment appends the placeholder of the length of a fragment.
+func reserveFragment(dst []byte) []byte {
+	return append(dst, 0, 0, 0, 0)
+}
+
+// closeFragment writes the length of the fragment that starts at start.
+func closeFragment(dst []byte, start int) []byte {
+	binary.BigEndian.PutUint32(dst[start-4:], uint32(len(dst)-start))
+	return dst
+}
+
+// extractFields appends the fragments of the encoder with the index i of count encoders to dst.
+func extractFields(dst, fields []byte, i, count int) []byte {
+	for j := 0; len(fields) > 0; j++ {
+		n := binary.BigEndian.Uint32(fields)
+		if j%count == i {
+			dst = append(dst, fields[4:4+n]...)
+		}
+		fields = fields[4+n:]
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendString(dst []byte, key, value string) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendString(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendInt(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendUint(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendFloat(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendBool(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendDuration(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendTime(dst, key, value), start)
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendError(dst []byte, key string, err error) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendError(dst, key, err), start)
+	}
+	return dst
+}
+
+// appendErrorArg appends the error among the args of a log as the field with the key. Only the encoders that need
+// the errors among the args get it; the others get empty fragments, so a console encoder does not add fields to
+// the logs of the writers.
+func (enc *multiEncoder) appendErrorArg(dst []byte, key string, err error) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		if c, ok := e.(errorArgEncoder); ok && c.isErrorArgNeeded() {
+			start := len(dst)
+			dst = closeFragment(e.AppendError(dst, key, err), start)
+		}
+	}
+	return dst
+}
+
+func (enc *multiEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	for _, e := range enc.encoders {
+		dst = reserveFragment(dst)
+		start := len(dst)
+		dst = closeFragment(e.AppendAny(dst, key, value), start)
+	}
+	return dst
+}
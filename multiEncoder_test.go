@@ -0,0 +1,152 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMultiEncoderFragments(t *testing.T) {
+	encoders := []Encoder{NewTextEncoder(), NewJSONEncoder(), NewCBOREncoder()}
+	multi := &multiEncoder{encoders: encoders}
+	appendFields := func(enc Encoder) []byte {
+		var dst []byte
+		dst = enc.AppendString(dst, "user", "bob")
+		dst = enc.AppendInt(dst, "id", -42)
+		dst = enc.AppendUint(dst, "size", 1<<40)
+		dst = enc.AppendFloat(dst, "ratio", 0.5)
+		dst = enc.AppendBool(dst, "ok", true)
+		dst = enc.AppendDuration(dst, "took", 1500*time.Microsecond)
+		dst = enc.AppendTime(dst, "at", time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC))
+		dst = enc.AppendError(dst, "error", errors.New("card declined"))
+		return enc.AppendAny(dst, "tags", []string{"a", "b"})
+	}
+
+	fields := appendFields(multi)
+	for i, enc := range encoders {
+		got := extractFields(nil, fields, i, len(encoders))
+		if want := appendFields(enc); !bytes.Equal(got, want) {
+			t.Errorf("fields of the encoder %d = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestMultiEncoderErrorArgs(t *testing.T) {
+	multi := &multiEncoder{encoders: []Encoder{NewJSONEncoder(), NewDevEncoder(&DevEncoderConfig{})}}
+	err := errors.New("boom")
+	fields := appendErrorArgs(multi, nil, []interface{}{"x ", err})
+	if got := extractFields(nil, fields, 0, 2); len(got) != 0 {
+		t.Errorf("fields of the JSON encoder = %q, want none", got)
+	}
+	if got, want := extractFields(nil, fields, 1, 2), NewDevEncoder(&DevEncoderConfig{}).AppendError(nil, "error", err); !bytes.Equal(got, want) {
+		t.Errorf("fields of the dev encoder = %q, want %q", got, want)
+	}
+}
+
+func TestConsoleEncoderKeepsWriterFormat(t *testing.T) {
+	newLogger := func(consoleEncoder Encoder) (logger *StandardLogger, file, console *bytes.Buffer) {
+		file, console = &bytes.Buffer{}, &bytes.Buffer{}
+		logger = NewStandardLogger(&StandardLoggerConfig{
+			IsWritingToTheConsole: true,
+			ErrorWriter:           file,
+			Encoder:               NewJSONEncoder(),
+			ConsoleEncoder:        consoleEncoder,
+		})
+		logger.console = console
+		logger.isColoredConsole = false
+		return logger, file, console
+	}
+	log := func(logger *StandardLogger) {
+		logger.With("order", 42).Error("payment failed: ", errors.New("card declined"))
+		logger.ErrorEvent().Err(errors.New("timeout")).Msg("retry")
+	}
+
+	plain, plainFile, _ := newLogger(nil)
+	log(plain)
+	dev, devFile, devConsole := newLogger(NewDevEncoder(&DevEncoderConfig{}))
+	log(dev)
+
+	if devFile.String() != plainFile.String() {
+		t.Errorf("the file with the dev console =\n%s\nwant the file without it =\n%s", devFile, plainFile)
+	}
+	for _, want := range []string{"ERROR   payment failed: card declined", "order=42", "    error: card declined\n",
+		"    error: timeout\n"} {
+		if !strings.Contains(devConsole.String(), want) {
+			t.Errorf("the console =\n%s\nwant it to contain %q", devConsole, want)
+		}
+	}
+}
+
+func TestAddEncoder(t *testing.T) {
+	json, logfmt := NewJSONEncoder(), NewLogfmtEncoder()
+	logger := NewStandardLogger(&StandardLoggerConfig{
+		Encoder:       json,
+		InfoEncoder:   json,
+		ErrorEncoder:  logfmt,
+		DebugEncoder:  logfmt,
+		RecordEncoder: NewGELFEncoder("host"),
+	})
+	if len(logger.encoders) != 3 {
+		t.Fatalf("the logger has %d encoders, want 3", len(logger.encoders))
+	}
+	want := map[Level]int{LevelTrace: 0, LevelDebug: 1, LevelInfo: 0, LevelError: 1, LevelFatal: 0, levelRecord: 2}
+	for level, i := range want {
+		if got := logger.writerEncoder(level); got != i {
+			t.Errorf("writerEncoder(%v) = %d, want %d", level, got, i)
+		}
+	}
+	if _, ok := logger.fieldEncoder().(*multiEncoder); !ok {
+		t.Errorf("fieldEncoder() = %T, want *multiEncoder", logger.fieldEncoder())
+	}
+
+	logger = NewStandardLogger(&StandardLoggerConfig{Encoder: json, ErrorEncoder: json, RecordEncoder: json})
+	if len(logger.encoders) != 1 || logger.fieldEncoder() != Encoder(json) {
+		t.Errorf("the logger with one encoder has %d encoders and the field encoder %T, want 1 and *JSONEncoder",
+			len(logger.encoders), logger.fieldEncoder())
+	}
+}
+
+func TestPerWriterEncoders(t *testing.T) {
+	var info, errs, console bytes.Buffer
+	cfg := StandardLoggerConfig{
+		IsWritingToTheConsole: true,
+		InfoWriter:            &info,
+		ErrorWriter:           &errs,
+		ErrorEncoder:          NewJSONEncoder(),
+	}
+	log := func(logger Logger) {
+		logger.Info("first")
+		With(logger, "id", 1).Error("second")
+		logger.Raw([]byte("raw\n"))
+		logger.InfoKV("third", "id", 2)
+	}
+	check := func(name string) {
+		t.Helper()
+		if want := "first\nthird id=2\n"; info.String() != want {
+			t.Errorf("%s wrote %q to the info writer, want %q", name, info.String(), want)
+		}
+		if want := `{"level":"error","msg":"second","id":1}` + "\n"; errs.String() != want {
+			t.Errorf("%s wrote %q to the error writer, want %q", name, errs.String(), want)
+		}
+		if want := "first\nid=1 second\nraw\nthird id=2\n"; console.String() != want {
+			t.Errorf("%s wrote %q to the console, want %q", name, console.String(), want)
+		}
+	}
+
+	std := NewStandardLogger(&cfg)
+	std.console, std.isColoredConsole = &console, false
+	log(std)
+	check("StandardLogger")
+
+	info.Reset()
+	errs.Reset()
+	console.Reset()
+	fast := NewFastLogger(&FastLoggerConfig{StandardLoggerConfig: cfg, FlushInterval: time.Hour})
+	defer fast.Stop()
+	fast.stdLogger.console, fast.stdLogger.isColoredConsole = &console, false
+	log(fast)
+	fast.Flush()
+	check("FastLogger")
+}
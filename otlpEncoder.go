@@ -0,0 +1,162 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"math"
+	"strconv"
+	"time"
+)
+
+// OTLPEncoder encodes entries into the JSON log records of OpenTelemetry (OTLP/JSON), one per line:
+//
+//	{"timeUnixNano":"1696161600000000000","severityNumber":9,"severityText":"info","body":{"stringValue":"order created"},"attributes":[{"key":"id","value":{"intValue":"42"}}]}
+//
+// The severity number of a level is TRACE (1) for trace messages, DEBUG (5) for debug messages, INFO (9) for
+// information and records, INFO2 (10) for successes, WARN (13) for warnings, ERROR (17) for errors and FATAL (21) for
+// fatal errors; the severity text is the label of the level or its name. The caller is written as the code.filepath
+// and code.lineno attributes. Durations are written as doubles of milliseconds, times in RFC 3339 format and values of
+// unknown types as strings formatted by fmt.
+//
+// Use an OTLPWriter to export the records to an OpenTelemetry collector.
+type OTLPEncoder struct{}
+
+// NewOTLPEncoder creates a new OTLPEncoder.
+func NewOTLPEncoder() *OTLPEncoder {
+	return &OTLPEncoder{}
+}
+
+// otlpSeverityNumber returns the severity number of the level in the OpenTelemetry logs data model.
+func otlpSeverityNumber(level Level) int {
+	switch level {
+	case LevelTrace:
+		return 1
+	case LevelDebug:
+		return 5
+	case LevelSuccess:
+		return 10
+	case LevelWarning:
+		return 13
+	case LevelError:
+		return 17
+	case LevelFatal:
+		return 21
+	default:
+		return 9
+	}
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *OTLPEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	dst = append(dst, '{')
+	if entry.Date != nil {
+		dst = append(dst, `"timeUnixNano":"`...)
+		dst = strconv.AppendInt(dst, entry.Time.UnixNano(), 10)
+		dst = append(dst, `",`...)
+	}
+	dst = append(dst, `"severityNumber":`...)
+	dst = strconv.AppendInt(dst, int64(otlpSeverityNumber(entry.Level)), 10)
+	dst = append(dst, `,"severityText":"`...)
+	if entry.Label != nil {
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Label))
+	} else {
+		dst = append(dst, entry.Level.String()...)
+	}
+	dst = append(dst, '"')
+	if len(entry.Prefix) > 0 || len(entry.Message) > 0 {
+		dst = append(dst, `,"body":{"stringValue":"`...)
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Prefix))
+		dst = appendJSONString(dst, fastbytes.B2S(entry.Message))
+		dst = append(dst, `"}`...)
+	}
+	attributes := len(dst)
+	if len(entry.Caller) > 0 {
+		file, line := splitCaller(entry.Caller)
+		dst = enc.AppendString(dst, "code.filepath", file)
+		if len(line) > 0 {
+			dst = append(enc.appendKey(dst, "code.lineno"), `{"intValue":"`...)
+			dst = append(dst, line...)
+			dst = append(dst, `"}}`...)
+		}
+	}
+	dst = append(dst, entry.Context...)
+	dst = append(dst, entry.Fields...)
+	if len(dst) > attributes {
+		// replace the comma before the first attribute with the beginning of the array
+		const start = `,"attributes":[`
+		dst = append(dst, start[1:]...)
+		copy(dst[attributes+len(start)-1:], dst[attributes:len(dst)-len(start)+1])
+		copy(dst[attributes:], start)
+		dst = append(dst, ']')
+	}
+	return append(dst, '}', '\n')
+}
+
+func (enc *OTLPEncoder) appendKey(dst []byte, key string) []byte {
+	dst = append(dst, `,{"key":"`...)
+	dst = appendJSONString(dst, key)
+	return append(dst, `","value":`...)
+}
+
+func (enc *OTLPEncoder) AppendString(dst []byte, key, value string) []byte {
+	dst = append(enc.appendKey(dst, key), `{"stringValue":"`...)
+	dst = appendJSONString(dst, value)
+	return append(dst, `"}}`...)
+}
+
+func (enc *OTLPEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	dst = append(enc.appendKey(dst, key), `{"intValue":"`...)
+	dst = strconv.AppendInt(dst, value, 10)
+	return append(dst, `"}}`...)
+}
+
+// AppendUint appends the integer. Integers larger than math.MaxInt64, which OTLP can't represent, are written
+// as strings.
+func (enc *OTLPEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	if value > math.MaxInt64 {
+		return enc.AppendString(dst, key, strconv.FormatUint(value, 10))
+	}
+	return enc.AppendInt(dst, key, int64(value))
+}
+
+func (enc *OTLPEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	dst = append(enc.appendKey(dst, key), `{"doubleValue":`...)
+	switch {
+	case math.IsNaN(value):
+		dst = append(dst, `"NaN"`...)
+	case math.IsInf(value, 1):
+		dst = append(dst, `"Infinity"`...)
+	case math.IsInf(value, -1):
+		dst = append(dst, `"-Infinity"`...)
+	default:
+		dst = strconv.AppendFloat(dst, value, 'f', -1, 64)
+	}
+	return append(dst, `}}`...)
+}
+
+func (enc *OTLPEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	dst = append(enc.appendKey(dst, key), `{"boolValue":`...)
+	dst = strconv.AppendBool(dst, value)
+	return append(dst, `}}`...)
+}
+
+// AppendDuration appends the duration as a double of milliseconds.
+func (enc *OTLPEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	return enc.AppendFloat(dst, key, float64(value)/float64(time.Millisecond))
+}
+
+// AppendTime appends the time as a string in RFC 3339 format.
+func (enc *OTLPEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	dst = append(enc.appendKey(dst, key), `{"stringValue":"`...)
+	dst = appendTime(dst, value)
+	return append(dst, `"}}`...)
+}
+
+func (enc *OTLPEncoder) AppendError(dst []byte, key string, err error) []byte {
+	return enc.AppendString(dst, key, err.Error())
+}
+
+// AppendAny appends the value as a string formatted by fmt.
+func (enc *OTLPEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	return enc.AppendString(dst, key, fmt.Sprint(value))
+}
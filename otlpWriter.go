@@ -0,0 +1,247 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultOTLPEndpoint     = "http://localhost:4318/v1/logs"
+	defaultOTLPMaxBatchSize = 512
+	defaultOTLPMaxRetries   = 3
+	defaultOTLPQueueSize    = 16
+	otlpInitialBackoff      = 100 * time.Millisecond
+)
+
+// ErrOTLPQueueFull is returned by OTLPWriter.Write when the queue of batches is full and the batch is dropped.
+var ErrOTLPQueueFull = errors.New("logger: the OTLP queue is full")
+
+// ErrOTLPWriterClosed is returned by OTLPWriter.Write after Close.
+var ErrOTLPWriterClosed = errors.New("logger: the OTLP writer is closed")
+
+// OTLPWriterConfig is the configuration of an OTLPWriter.
+type OTLPWriterConfig struct {
+	// Endpoint is the URL of the OTLP/HTTP logs endpoint. By default, it's "http://localhost:4318/v1/logs".
+	Endpoint string
+	// Headers are added to every request, like authorization headers.
+	Headers map[string]string
+	// ServiceName is the service.name resource attribute.
+	ServiceName string
+	// ResourceAttributes are the other attributes of the resource, like "deployment.environment".
+	ResourceAttributes map[string]string
+	// Client sends the requests. By default, it's an http.Client with a 10-second timeout.
+	Client *http.Client
+	// MaxBatchSize is the maximum count of log records in a request. By default, it's 512.
+	MaxBatchSize int
+	// MaxRetries is the maximum count of retries of a request that failed with a network error or with a 429, 502, 503
+	// or 504 status. By default, it's 3, and a negative value disables the retries. The first retry is after 100ms, and
+	// the delay doubles after every retry. The requests are not retried after Close.
+	MaxRetries int
+	// QueueSize is the maximum count of batches waiting to be exported. By default, it's 16.
+	QueueSize int
+	// ErrorFunc is called from the exporting goroutine when a batch is dropped after all retries.
+	ErrorFunc func(err error)
+}
+
+// OTLPWriter is an io.Writer that exports the lines written by an OTLPEncoder to an OpenTelemetry collector
+// over OTLP/HTTP with JSON encoding. Every Write is exported as a batch of log records, so with a FastLogger
+// with UnifiedWriter, the batches are the logs of a FlushInterval. The batches are exported by a goroutine, so
+// the logger doesn't wait for the collector; Write drops the batch and returns ErrOTLPQueueFull if the queue is full.
+// Call Close before shutting down the application to export the queued batches.
+//
+// Example:
+//
+//	otlpWriter := logger.NewOTLPWriter(&logger.OTLPWriterConfig{ServiceName: "billing"})
+//	defer otlpWriter.Close()
+//	log := logger.NewFastLogger(&logger.FastLoggerConfig{
+//		StandardLoggerConfig: logger.StandardLoggerConfig{
+//			UnifiedWriter: otlpWriter,
+//			ShowDate:      true,
+//			Encoder:       logger.NewOTLPEncoder(),
+//		},
+//		FlushInterval: time.Second,
+//	})
+//	defer log.Flush()
+type OTLPWriter struct {
+	endpoint     string
+	headers      map[string]string
+	client       *http.Client
+	maxBatchSize int
+	maxRetries   int
+	errorFunc    func(err error)
+	// prefix is the beginning of the body of a request with the resource and the scope.
+	prefix []byte
+
+	mutex    sync.Mutex
+	isClosed bool
+	queue    chan []byte
+	// closed is closed by Close to stop waiting for the retries.
+	closed chan struct{}
+	done   chan struct{}
+}
+
+// NewOTLPWriter creates a new OTLPWriter and starts its exporting goroutine.
+func NewOTLPWriter(cfg *OTLPWriterConfig) *OTLPWriter {
+	w := &OTLPWriter{
+		endpoint:     cfg.Endpoint,
+		headers:      cfg.Headers,
+		client:       cfg.Client,
+		maxBatchSize: cfg.MaxBatchSize,
+		maxRetries:   cfg.MaxRetries,
+		errorFunc:    cfg.ErrorFunc,
+		closed:       make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	if w.endpoint == "" {
+		w.endpoint = defaultOTLPEndpoint
+	}
+	if w.client == nil {
+		w.client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if w.maxBatchSize <= 0 {
+		w.maxBatchSize = defaultOTLPMaxBatchSize
+	}
+	if w.maxRetries == 0 {
+		w.maxRetries = defaultOTLPMaxRetries
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = defaultOTLPQueueSize
+	}
+	w.queue = make(chan []byte, queueSize)
+
+	enc := NewOTLPEncoder()
+	var attributes []byte
+	if cfg.ServiceName != "" {
+		attributes = enc.AppendString(attributes, "service.name", cfg.ServiceName)
+	}
+	for key, value := range cfg.ResourceAttributes {
+		attributes = enc.AppendString(attributes, key, value)
+	}
+	w.prefix = append(w.prefix, `{"resourceLogs":[{"resource":{"attributes":[`...)
+	if len(attributes) > 0 {
+		w.prefix = append(w.prefix, attributes[1:]...)
+	}
+	w.prefix = append(w.prefix, `]},"scopeLogs":[{"scope":{"name":"`...)
+	w.prefix = appendJSONString(w.prefix, packagePrefix[:len(packagePrefix)-1])
+	w.prefix = append(w.prefix, `"},"logRecords":[`...)
+
+	go w.export()
+	return w
+}
+
+// Write queues the log records, one per line, to be exported.
+func (w *OTLPWriter) Write(p []byte) (int, error) {
+	batch := make([]byte, len(p))
+	copy(batch, p)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.isClosed {
+		return 0, ErrOTLPWriterClosed
+	}
+	select {
+	case w.queue <- batch:
+		return len(p), nil
+	default:
+		return 0, ErrOTLPQueueFull
+	}
+}
+
+// Close exports the queued batches and stops the exporting goroutine. A request that is waiting to be retried fails
+// right away.
+func (w *OTLPWriter) Close() error {
+	w.mutex.Lock()
+	if !w.isClosed {
+		w.isClosed = true
+		close(w.queue)
+		close(w.closed)
+	}
+	w.mutex.Unlock()
+	<-w.done
+	return nil
+}
+
+func (w *OTLPWriter) export() {
+	defer close(w.done)
+	var body []byte
+	for batch := range w.queue {
+		for len(batch) > 0 {
+			body = append(body[:0], w.prefix...)
+			count := 0
+			for count < w.maxBatchSize && len(batch) > 0 {
+				record := batch
+				if i := bytes.IndexByte(batch, '\n'); i >= 0 {
+					record, batch = batch[:i], batch[i+1:]
+				} else {
+					batch = nil
+				}
+				if len(record) == 0 {
+					continue
+				}
+				if count > 0 {
+					body = append(body, ',')
+				}
+				body = append(body, record...)
+				count++
+			}
+			if count == 0 {
+				break
+			}
+			body = append(body, "]}]}]}"...)
+			if err := w.send(body); err != nil && w.errorFunc != nil {
+				w.errorFunc(err)
+			}
+		}
+	}
+}
+
+// send posts the body, retrying if the collector is unavailable, until the writer is closed.
+func (w *OTLPWriter) send(body []byte) error {
+	backoff := otlpInitialBackoff
+	for attempt := 0; ; attempt++ {
+		isRetryable, err := w.post(body)
+		if err == nil || !isRetryable || attempt >= w.maxRetries {
+			return err
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-w.closed:
+			timer.Stop()
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+func (w *OTLPWriter) post(body []byte) (isRetryable bool, err error) {
+	req, err := http.NewRequest(http.MethodPost, w.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusBadGateway ||
+		resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusGatewayTimeout:
+		return true, fmt.Errorf("logger: the OTLP endpoint responded with %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("logger: the OTLP endpoint responded with %d", resp.StatusCode)
+	}
+}
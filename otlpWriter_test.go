@@ -0,0 +1,235 @@
+package logger_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/Eugene-Usachev/logger"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// otlpCollector is an OTLP/HTTP endpoint that responds with the statuses in order and then with 200.
+type otlpCollector struct {
+	mutex    sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+// wait waits until the collector got the count of requests.
+func (collector *otlpCollector) wait(t *testing.T, count int) {
+	t.Helper()
+	for deadline := time.Now().Add(5 * time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		collector.mutex.Lock()
+		got := len(collector.bodies)
+		collector.mutex.Unlock()
+		if got >= count {
+			return
+		}
+	}
+	t.Fatalf("the collector didn't get %d requests", count)
+}
+
+func newOTLPCollector(t *testing.T, statuses ...int) (*otlpCollector, *httptest.Server) {
+	collector := &otlpCollector{statuses: statuses}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		collector.mutex.Lock()
+		collector.bodies = append(collector.bodies, body)
+		collector.headers = append(collector.headers, r.Header.Clone())
+		status := http.StatusOK
+		if len(collector.statuses) > 0 {
+			status, collector.statuses = collector.statuses[0], collector.statuses[1:]
+		}
+		collector.mutex.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return collector, server
+}
+
+// otlpRequest is the part of an OTLP/HTTP JSON request checked by the tests.
+type otlpRequest struct {
+	ResourceLogs []struct {
+		Resource struct {
+			Attributes []struct {
+				Key   string `json:"key"`
+				Value struct {
+					StringValue string `json:"stringValue"`
+				} `json:"value"`
+			} `json:"attributes"`
+		} `json:"resource"`
+		ScopeLogs []struct {
+			LogRecords []struct {
+				SeverityText string `json:"severityText"`
+				Body         struct {
+					StringValue string `json:"stringValue"`
+				} `json:"body"`
+			} `json:"logRecords"`
+		} `json:"scopeLogs"`
+	} `json:"resourceLogs"`
+}
+
+func parseOTLPRequest(t *testing.T, body []byte) (messages []string, attributes map[string]string) {
+	t.Helper()
+	var req otlpRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("the request is not JSON: %v\n%s", err, body)
+	}
+	attributes = map[string]string{}
+	for _, resourceLogs := range req.ResourceLogs {
+		for _, attribute := range resourceLogs.Resource.Attributes {
+			attributes[attribute.Key] = attribute.Value.StringValue
+		}
+		for _, scopeLogs := range resourceLogs.ScopeLogs {
+			for _, record := range scopeLogs.LogRecords {
+				messages = append(messages, record.Body.StringValue)
+			}
+		}
+	}
+	return messages, attributes
+}
+
+func TestOTLPWriterRetry(t *testing.T) {
+	collector, server := newOTLPCollector(t, http.StatusServiceUnavailable)
+	var errs []error
+	w := logger.NewOTLPWriter(&logger.OTLPWriterConfig{
+		Endpoint:    server.URL,
+		Headers:     map[string]string{"Authorization": "Bearer token"},
+		ServiceName: "billing",
+		ErrorFunc:   func(err error) { errs = append(errs, err) },
+	})
+	log := logger.NewStandardLogger(&logger.StandardLoggerConfig{
+		UnifiedWriter: w,
+		Encoder:       logger.NewOTLPEncoder(),
+	})
+	log.Info("order created")
+	collector.wait(t, 2)
+	w.Close()
+
+	if len(errs) > 0 {
+		t.Errorf("ErrorFunc was called with %v", errs)
+	}
+	if len(collector.bodies) != 2 {
+		t.Fatalf("the collector got %d requests, want 2: the failed one and the retry", len(collector.bodies))
+	}
+	if !bytes.Equal(collector.bodies[0], collector.bodies[1]) {
+		t.Errorf("the retry has another body:\n%s\n%s", collector.bodies[0], collector.bodies[1])
+	}
+	for _, header := range collector.headers {
+		if header.Get("Content-Type") != "application/json" || header.Get("Authorization") != "Bearer token" {
+			t.Errorf("headers = %v, want the JSON content type and the configured headers", header)
+		}
+	}
+	messages, attributes := parseOTLPRequest(t, collector.bodies[1])
+	if len(messages) != 1 || messages[0] != "order created" {
+		t.Errorf("messages = %q, want [\"order created\"]", messages)
+	}
+	if attributes["service.name"] != "billing" {
+		t.Errorf("service.name = %q, want \"billing\"", attributes["service.name"])
+	}
+}
+
+func TestOTLPWriterBatches(t *testing.T) {
+	collector, server := newOTLPCollector(t)
+	w := logger.NewOTLPWriter(&logger.OTLPWriterConfig{Endpoint: server.URL, MaxBatchSize: 2})
+	enc := logger.NewOTLPEncoder()
+	var flushed []byte
+	for _, msg := range []string{"a", "b", "c"} {
+		flushed = enc.AppendEntry(flushed, logger.Entry{Level: logger.LevelInfo, Message: []byte(msg)})
+	}
+	if _, err := w.Write(flushed); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	w.Close()
+
+	want := [][]string{{"a", "b"}, {"c"}}
+	if len(collector.bodies) != len(want) {
+		t.Fatalf("the collector got %d requests, want %d", len(collector.bodies), len(want))
+	}
+	for i, body := range collector.bodies {
+		messages, _ := parseOTLPRequest(t, body)
+		if len(messages) != len(want[i]) || messages[0] != want[i][0] {
+			t.Errorf("request %d has the messages %q, want %q", i, messages, want[i])
+		}
+	}
+}
+
+func TestOTLPWriterNotRetryable(t *testing.T) {
+	collector, server := newOTLPCollector(t, http.StatusBadRequest)
+	var errs []error
+	w := logger.NewOTLPWriter(&logger.OTLPWriterConfig{
+		Endpoint:  server.URL,
+		ErrorFunc: func(err error) { errs = append(errs, err) },
+	})
+	w.Write(logger.NewOTLPEncoder().AppendEntry(nil, logger.Entry{Level: logger.LevelError, Message: []byte("x")}))
+	w.Close()
+
+	if len(collector.bodies) != 1 {
+		t.Errorf("the collector got %d requests, want 1", len(collector.bodies))
+	}
+	if len(errs) != 1 {
+		t.Errorf("ErrorFunc was called %d times, want once", len(errs))
+	}
+	if _, err := w.Write([]byte("{}\n")); err != logger.ErrOTLPWriterClosed {
+		t.Errorf("Write() after Close() error = %v, want ErrOTLPWriterClosed", err)
+	}
+}
+
+func TestOTLPWriterMaxRetries(t *testing.T) {
+	tests := []struct {
+		maxRetries   int
+		wantRequests int
+	}{
+		{-1, 1},
+		{1, 2},
+		{0, 4},
+	}
+	for _, test := range tests {
+		collector, server := newOTLPCollector(t, http.StatusServiceUnavailable, http.StatusServiceUnavailable,
+			http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		errs := make(chan error, 1)
+		w := logger.NewOTLPWriter(&logger.OTLPWriterConfig{
+			Endpoint:   server.URL,
+			MaxRetries: test.maxRetries,
+			ErrorFunc:  func(err error) { errs <- err },
+		})
+		w.Write(logger.NewOTLPEncoder().AppendEntry(nil, logger.Entry{Level: logger.LevelInfo, Message: []byte("x")}))
+		select {
+		case <-errs:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("MaxRetries %d: the batch wasn't dropped", test.maxRetries)
+		}
+		w.Close()
+		if len(collector.bodies) != test.wantRequests {
+			t.Errorf("MaxRetries %d: the collector got %d requests, want %d",
+				test.maxRetries, len(collector.bodies), test.wantRequests)
+		}
+	}
+}
+
+func TestOTLPWriterCloseStopsRetries(t *testing.T) {
+	collector, server := newOTLPCollector(t, http.StatusServiceUnavailable)
+	var errs []error
+	w := logger.NewOTLPWriter(&logger.OTLPWriterConfig{
+		Endpoint:   server.URL,
+		MaxRetries: 10,
+		ErrorFunc:  func(err error) { errs = append(errs, err) },
+	})
+	w.Write(logger.NewOTLPEncoder().AppendEntry(nil, logger.Entry{Level: logger.LevelInfo, Message: []byte("x")}))
+	collector.wait(t, 1)
+
+	start := time.Now()
+	w.Close()
+	if took := time.Since(start); took > time.Second {
+		t.Errorf("Close() took %v, want it not to wait for the retries", took)
+	}
+	if len(collector.bodies) != 1 || len(errs) != 1 {
+		t.Errorf("the collector got %d requests and ErrorFunc was called %d times, want 1 and 1",
+			len(collector.bodies), len(errs))
+	}
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"context"
+	"github.com/Eugene-Usachev/fastbytes"
+	"log/slog"
+)
+
+// SlogHandler is a slog.Handler that writes to the buffers of a FastLogger.
+//
+// slog levels are mapped onto the levels of the logger: levels below slog.LevelDebug go to LevelTrace,
+// slog.LevelDebug to LevelDebug, slog.LevelInfo to LevelInfo, slog.LevelWarn to LevelWarning and slog.LevelError and
+// above to LevelError. The attributes of WithAttrs are encoded once, like the fields of FastLogger.With.
+// Groups are written as prefixes of the keys, like "request.id=1".
+//
+// Example:
+//
+//	slog.SetDefault(slog.New(logger.NewSlogHandler(fastLogger)))
+type SlogHandler struct {
+	logger *FastLogger
+	// group is the prefix of the keys of the next attributes, like "request.".
+	group string
+}
+
+var _ slog.Handler = (*SlogHandler)(nil)
+
+// NewSlogHandler creates a new SlogHandler that writes to the logger.
+func NewSlogHandler(logger *FastLogger) *SlogHandler {
+	return &SlogHandler{logger: logger}
+}
+
+// levelFromSlog returns the level of the logger for the slog level.
+func levelFromSlog(level slog.Level) Level {
+	switch {
+	case level < slog.LevelDebug:
+		return LevelTrace
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarning
+	default:
+		return LevelError
+	}
+}
+
+// Enabled reports whether the logger writes logs of the level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.stdLogger.isEnabled(levelFromSlog(level))
+}
+
+// Handle writes the record to the buffer of its level. The log is dated with the time of the record, or with the time
+// of the clock if the record has no time.
+func (h *SlogHandler) Handle(_ context.Context, record slog.Record) error {
+	fields := Builder()
+	record.Attrs(func(attr slog.Attr) bool {
+		fields.rec = appendSlogAttr(h.logger.fieldEncoder(), fields.rec, h.group, attr)
+		return true
+	})
+	h.logger.logEntryAt(levelFromSlog(record.Level), record.Time, fastbytes.S2B(record.Message), fields.rec)
+	fields.release()
+	return nil
+}
+
+// WithAttrs returns a handler that writes the attributes to every log. The attributes are encoded once, here.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	var fields []byte
+	for _, attr := range attrs {
+		fields = appendSlogAttr(h.logger.stdLogger.fieldEncoder(), fields, h.group, attr)
+	}
+	if len(fields) == 0 {
+		return h
+	}
+	return &SlogHandler{
+		logger: &FastLogger{
+			stdLogger: h.logger.stdLogger.withFields(fields),
+			buffers:   h.logger.buffers,
+			fatalFunc: h.logger.fatalFunc,
+		},
+		group: h.group,
+	}
+}
+
+// WithGroup returns a handler that prefixes the keys of the next attributes with the name of the group.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &SlogHandler{
+		logger: h.logger,
+		group:  h.group + name + ".",
+	}
+}
+
+// appendSlogAttr appends the attribute to dst as a field, encoded by the encoder. The keys of the attributes of groups
+// are prefixed with the name of the group.
+func appendSlogAttr(enc Encoder, dst []byte, group string, attr slog.Attr) []byte {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return dst
+	}
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			group += attr.Key + "."
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			dst = appendSlogAttr(enc, dst, group, groupAttr)
+		}
+		return dst
+	}
+	key := attr.Key
+	if group != "" {
+		key = group + key
+	}
+	value := attr.Value
+	switch value.Kind() {
+	case slog.KindString:
+		return enc.AppendString(dst, key, value.String())
+	case slog.KindInt64:
+		return enc.AppendInt(dst, key, value.Int64())
+	case slog.KindUint64:
+		return enc.AppendUint(dst, key, value.Uint64())
+	case slog.KindFloat64:
+		return enc.AppendFloat(dst, key, value.Float64())
+	case slog.KindBool:
+		return enc.AppendBool(dst, key, value.Bool())
+	case slog.KindDuration:
+		return enc.AppendDuration(dst, key, value.Duration())
+	case slog.KindTime:
+		return enc.AppendTime(dst, key, value.Time())
+	default:
+		return appendField(enc, dst, key, value.Any())
+	}
+}
@@ -0,0 +1,67 @@
+package logger_test
+
+import (
+	"context"
+	"github.com/Eugene-Usachev/logger"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func newSlogLogger(t *testing.T, w *levelWriters, showDate bool) (*slog.Logger, *logger.FastLogger) {
+	cfg := w.config()
+	cfg.ShowDate = showDate
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: cfg, FlushInterval: time.Hour})
+	t.Cleanup(fastLogger.Stop)
+	return slog.New(logger.NewSlogHandler(fastLogger)), fastLogger
+}
+
+func TestSlogHandlerLevels(t *testing.T) {
+	var w levelWriters
+	log, fastLogger := newSlogLogger(t, &w, false)
+	log.Log(context.Background(), slog.LevelDebug-4, "trace")
+	log.Debug("debug")
+	log.Info("info", "id", 42)
+	log.Warn("warning")
+	log.Error("error")
+	log.Log(context.Background(), slog.LevelError+4, "critical")
+	fastLogger.Flush()
+	checkLevelWriters(t, &w, map[string]string{
+		"trace":   "trace\n",
+		"debug":   "debug\n",
+		"info":    "info id=42\n",
+		"warning": "warning\n",
+		"error":   "error\ncritical\n",
+	})
+}
+
+func TestSlogHandlerAttrsAndGroups(t *testing.T) {
+	var w levelWriters
+	log, fastLogger := newSlogLogger(t, &w, false)
+	requestLog := log.With("service", "billing").WithGroup("request").With("id", 7)
+	requestLog.Info("started", "path", "/pay", slog.Group("user", "name", "bob smith"))
+	log.WithGroup("").Info("plain", slog.Group("", "inline", true), slog.Attr{})
+	log.Info("after", "took", 1500*time.Microsecond)
+	fastLogger.Flush()
+	checkLevelWriters(t, &w, map[string]string{
+		"info": `service=billing request.id=7 started request.path=/pay request.user.name="bob smith"` + "\n" +
+			"plain inline=true\n" +
+			"after took=1.5ms\n",
+	})
+}
+
+func TestSlogHandlerRecordTime(t *testing.T) {
+	var w levelWriters
+	_, fastLogger := newSlogLogger(t, &w, true)
+	handler := logger.NewSlogHandler(fastLogger)
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.Local)
+	record := slog.NewRecord(at, slog.LevelInfo, "replayed", 0)
+	record.AddAttrs(slog.Int("id", 42))
+	if err := handler.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	fastLogger.Flush()
+	if want := "2023/10/01 12:00:00 replayed id=42\n"; w.info.String() != want {
+		t.Errorf("the log of a record with a time = %q, want %q", w.info.String(), want)
+	}
+}
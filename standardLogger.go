@@ -1,11 +1,11 @@
 package logger
 
 import (
-	"fmt"
-	"github.com/Eugene-Usachev/fastbytes"
+	"context"
 	"io"
 	"os"
-	"strconv"
+	"reflect"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,6 +13,8 @@ var std = os.Stderr
 
 type StandardLogger struct {
 	console io.Writer
+	// isColoredConsole indicates whether the logs are colored by level in the console. The writers never get colors.
+	isColoredConsole bool
 
 	// errorWriter is the writer to which errors will be written.
 	errorWriter io.Writer
@@ -28,12 +30,55 @@ type StandardLogger struct {
 	recordWriter io.Writer
 	// rawWriter is the writer to which raw logs will be written.
 	rawWriter io.Writer
+	// debugWriter is the writer to which debug messages will be written.
+	debugWriter io.Writer
+	// traceWriter is the writer to which trace messages will be written.
+	traceWriter io.Writer
+
+	// unifiedWriter is the writer to which all logs are written in the unified mode. It is nil in other modes.
+	unifiedWriter io.Writer
 
 	showDate bool
+	// timeFormat is the format of the dates of the logs.
+	timeFormat string
+	// dates is the cached time of the clock with its date in the timeFormat. It is shared with the children
+	// of the logger.
+	dates *atomic.Pointer[timestamp]
+	// isPreciseTime indicates whether the logs are stamped with the time of the call instead of the clock.
+	isPreciseTime bool
+	// preciseLayout is the timeFormat split around its fractional seconds for the precise time.
+	preciseLayout dateLayout
+	// seconds is the cached date of the current second for the precise time. It is shared with the children
+	// of the logger.
+	seconds *atomic.Pointer[secondDate]
+	// showCaller indicates whether the entries have the callers.
+	showCaller bool
+	// labels are the labels of the levels from LevelTrace to LevelFatal. A level without a label has a nil label.
+	labels [LevelFatal + 1][]byte
+
+	// encoders are the distinct encoders of the logs. The first one is the default encoder.
+	encoders []Encoder
+	// consoleEncoder is the index of the encoder of the console in encoders.
+	consoleEncoder int
+	// levelEncoders are the indexes of the encoders of the writers of the levels in encoders.
+	levelEncoders [LevelFatal + 1]int
+	// recordEncoder is the index of the encoder of the records in encoders.
+	recordEncoder int
+	// separateConsole indicates whether the console has its own encoding for some levels. Then FastLogger buffers
+	// all logs of the console separately, to keep them in order.
+	separateConsole bool
+	// fields encodes the fields of the logs with all encoders.
+	fields Encoder
+	// level is the minimum level of logs to be written. It is stored as an int32 to be changed at runtime
+	// and is shared with the children of the logger.
+	level *atomic.Int32
+	// contexts are the fields of the logger, encoded by every encoder once, when the logger is created by With.
+	contexts [][]byte
 }
 
 type StandardLoggerConfig struct {
-	// IsWritingToTheConsole indicates whether the logger should write to the console.
+	// IsWritingToTheConsole indicates whether the logger should write to the console. The logs in the console are
+	// colored by level, unless the console is not a terminal or the NO_COLOR environment variable is not empty.
 	IsWritingToTheConsole bool
 	// ErrorWriter is the writer to which errors will be written.
 	ErrorWriter io.Writer
@@ -49,8 +94,52 @@ type StandardLoggerConfig struct {
 	RecordWriter io.Writer
 	// RawWriter is the writer to which raw logs will be written.
 	RawWriter io.Writer
+	// DebugWriter is the writer to which debug messages will be written.
+	DebugWriter io.Writer
+	// TraceWriter is the writer to which trace messages will be written.
+	TraceWriter io.Writer
+	// UnifiedWriter is the writer to which the logs of all levels, records and raw logs will be written
+	// in chronological order, instead of the writers above. Set it to os.Stdout for container platforms.
+	UnifiedWriter io.Writer
 
 	ShowDate bool
+	// TimeFormat is the format of the dates of the logs: one of the TimeFormat constants, like TimeFormatRFC3339Milli,
+	// or a layout of time.Format. By default, it's TimeFormatDefault. It applies to the encoders that write the date
+	// as text, like the text, JSON, logfmt, CSV and template encoders; the other encoders follow their schemas.
+	TimeFormat string
+	// PreciseTime indicates whether every log is stamped with the time of the call instead of the cached time of
+	// the clock. The date is still formatted once per second; only the fractional seconds are formatted for every log.
+	// A format without fractional seconds, like TimeFormatDefault or TimeFormatRFC3339, gets microseconds after
+	// its seconds, like "2023/10/01 12:00:00.000000". TimeFormatUnix stays in whole seconds.
+	PreciseTime bool
+	// ClockTick is the interval between the refreshes of the cached time of the logs. By default, it's 300ms.
+	// The clock is shared by all loggers, so the shortest tick of them is used from the next refresh.
+	ClockTick time.Duration
+	// ShowCaller indicates whether the location of the code that logged is written into the logs. Finding it makes
+	// logging slower. The encoders that need the caller, like a TemplateEncoder with {caller}, enable it by themselves.
+	ShowCaller bool
+	// LevelLabels are the labels of the levels rendered into each log, like DefaultLevelLabels.
+	// By default, the text logs have no labels, and the JSON and logfmt logs have the names of the levels.
+	LevelLabels map[Level]string
+	// Encoder encodes the logs. By default, it's a TextEncoder. Use NewJSONEncoder to write JSON lines.
+	// Raw logs are written as is.
+	Encoder Encoder
+	// ConsoleEncoder encodes the logs in the console. By default, it's Encoder. Use NewDevEncoder to read the logs
+	// easily during development, while the writers keep a machine format.
+	ConsoleEncoder Encoder
+	// ErrorEncoder, WarningEncoder, InfoEncoder, SuccessEncoder, FatalEncoder, RecordEncoder, DebugEncoder and
+	// TraceEncoder encode the logs of their writers. By default, they are Encoder. Every log is encoded once by
+	// each distinct encoder it is written with.
+	ErrorEncoder   Encoder
+	WarningEncoder Encoder
+	InfoEncoder    Encoder
+	SuccessEncoder Encoder
+	FatalEncoder   Encoder
+	RecordEncoder  Encoder
+	DebugEncoder   Encoder
+	TraceEncoder   Encoder
+	// Level is the minimum level of logs to be written. By default, it's LevelTrace, so all logs are written.
+	Level Level
 }
 
 // NewStandardLogger creates a new StandardLogger.
@@ -58,6 +147,7 @@ func NewStandardLogger(cfg *StandardLoggerConfig) *StandardLogger {
 	logger := &StandardLogger{}
 	if cfg.IsWritingToTheConsole {
 		logger.console = std
+		logger.isColoredConsole = isColorTerminal(std)
 	}
 
 	logger.errorWriter = cfg.ErrorWriter
@@ -67,53 +157,225 @@ func NewStandardLogger(cfg *StandardLoggerConfig) *StandardLogger {
 	logger.fatalWriter = cfg.FatalWriter
 	logger.recordWriter = cfg.RecordWriter
 	logger.rawWriter = cfg.RawWriter
+	logger.debugWriter = cfg.DebugWriter
+	logger.traceWriter = cfg.TraceWriter
+	if cfg.UnifiedWriter != nil {
+		logger.unifiedWriter = cfg.UnifiedWriter
+		logger.errorWriter = cfg.UnifiedWriter
+		logger.warningWriter = cfg.UnifiedWriter
+		logger.infoWriter = cfg.UnifiedWriter
+		logger.successWriter = cfg.UnifiedWriter
+		logger.fatalWriter = cfg.UnifiedWriter
+		logger.recordWriter = cfg.UnifiedWriter
+		logger.rawWriter = cfg.UnifiedWriter
+		logger.debugWriter = cfg.UnifiedWriter
+		logger.traceWriter = cfg.UnifiedWriter
+	}
 
 	logger.showDate = cfg.ShowDate
-
-	if len(Now.Load().([]byte)) == 0 {
-		go func() {
-			buf := make([]byte, 0, 19)
-			for {
-				time.Sleep(300 * time.Millisecond)
-				date := time.Now()
-				buf = make([]byte, 0, 19)
-				year, month, day := date.Date()
-				hour, min, sec := date.Clock()
-				buf = strconv.AppendInt(buf, int64(year), 10)
-				buf = append(buf, '/')
-				if month < 10 {
-					buf = append(buf, '0')
-				}
-				buf = strconv.AppendInt(buf, int64(month), 10)
-				buf = append(buf, '/')
-				if day < 10 {
-					buf = append(buf, '0')
-				}
-				buf = strconv.AppendInt(buf, int64(day), 10)
-				buf = append(buf, ' ')
-				if hour < 10 {
-					buf = append(buf, '0')
-				}
-				buf = strconv.AppendInt(buf, int64(hour), 10)
-				buf = append(buf, ':')
-				if min < 10 {
-					buf = append(buf, '0')
-				}
-				buf = strconv.AppendInt(buf, int64(min), 10)
-				buf = append(buf, ':')
-				if sec < 10 {
-					buf = append(buf, '0')
-				}
-				buf = strconv.AppendInt(buf, int64(sec), 10)
-				buf = append(buf, ' ')
-				Now.Store(buf)
-			}
-		}()
+	logger.timeFormat = cfg.TimeFormat
+	if logger.timeFormat == "" {
+		logger.timeFormat = TimeFormatDefault
+	}
+	logger.dates = &atomic.Pointer[timestamp]{}
+	logger.isPreciseTime = cfg.PreciseTime
+	logger.preciseLayout = newPreciseDateLayout(logger.timeFormat)
+	logger.seconds = &atomic.Pointer[secondDate]{}
+	for level, label := range cfg.LevelLabels {
+		if level >= LevelTrace && level <= LevelFatal {
+			logger.labels[level] = []byte(label)
+		}
+	}
+	encoder := cfg.Encoder
+	if encoder == nil {
+		encoder = NewTextEncoder()
+	}
+	logger.encoders = []Encoder{encoder}
+	levelEncoders := [LevelFatal + 1]Encoder{
+		LevelTrace:   cfg.TraceEncoder,
+		LevelDebug:   cfg.DebugEncoder,
+		LevelInfo:    cfg.InfoEncoder,
+		LevelSuccess: cfg.SuccessEncoder,
+		LevelWarning: cfg.WarningEncoder,
+		LevelError:   cfg.ErrorEncoder,
+		LevelFatal:   cfg.FatalEncoder,
+	}
+	for level, enc := range levelEncoders {
+		logger.levelEncoders[level] = logger.addEncoder(enc)
+	}
+	logger.recordEncoder = logger.addEncoder(cfg.RecordEncoder)
+	if logger.console != nil {
+		logger.consoleEncoder = logger.addEncoder(cfg.ConsoleEncoder)
+		logger.separateConsole = logger.consoleEncoder != logger.recordEncoder
+		for _, i := range logger.levelEncoders {
+			logger.separateConsole = logger.separateConsole || logger.consoleEncoder != i
+		}
+	}
+	logger.contexts = make([][]byte, len(logger.encoders))
+	logger.fields = encoder
+	if len(logger.encoders) > 1 {
+		logger.fields = &multiEncoder{encoders: logger.encoders}
+	}
+	logger.showCaller = cfg.ShowCaller
+	if enc, ok := logger.fields.(callerEncoder); ok && enc.isCallerNeeded() {
+		logger.showCaller = true
 	}
+	logger.writeHeaders()
+	logger.level = &atomic.Int32{}
+	logger.level.Store(int32(cfg.Level))
+
+	startClock(cfg.ClockTick)
 
 	return logger
 }
 
+// writeHeaders wraps the writers whose encoders have headers, so they write the header before their first log.
+// A writer of several levels writes the header of the first of them once. The console and the raw writer get no header.
+func (logger *StandardLogger) writeHeaders() {
+	wrapped := map[io.Writer]io.Writer{}
+	for _, w := range [...]struct {
+		writer  *io.Writer
+		encoder int
+	}{
+		{&logger.traceWriter, logger.levelEncoders[LevelTrace]},
+		{&logger.debugWriter, logger.levelEncoders[LevelDebug]},
+		{&logger.infoWriter, logger.levelEncoders[LevelInfo]},
+		{&logger.successWriter, logger.levelEncoders[LevelSuccess]},
+		{&logger.warningWriter, logger.levelEncoders[LevelWarning]},
+		{&logger.errorWriter, logger.levelEncoders[LevelError]},
+		{&logger.fatalWriter, logger.levelEncoders[LevelFatal]},
+		{&logger.recordWriter, logger.recordEncoder},
+		{&logger.unifiedWriter, 0},
+	} {
+		enc, ok := logger.encoders[w.encoder].(headerEncoder)
+		if *w.writer == nil || !ok || len(enc.headerRow()) == 0 {
+			continue
+		}
+		// writers of types that can't be map keys are never shared by the levels
+		if !reflect.TypeOf(*w.writer).Comparable() {
+			*w.writer = &headerWriter{writer: *w.writer, header: enc.headerRow()}
+			continue
+		}
+		if wrapper, ok := wrapped[*w.writer]; ok {
+			*w.writer = wrapper
+			continue
+		}
+		wrapper := &headerWriter{writer: *w.writer, header: enc.headerRow()}
+		wrapped[*w.writer] = wrapper
+		*w.writer = wrapper
+	}
+}
+
+// SetLevel sets the minimum level of logs to be written. It is safe to call it while the logger is in use.
+func (logger *StandardLogger) SetLevel(level Level) {
+	logger.level.Store(int32(level))
+}
+
+// Level returns the minimum level of logs to be written.
+func (logger *StandardLogger) Level() Level {
+	return Level(logger.level.Load())
+}
+
+// isEnabled reports whether logs of the level should be written.
+func (logger *StandardLogger) isEnabled(level Level) bool {
+	return level >= Level(logger.level.Load())
+}
+
+// With returns a child logger that writes the fields to every log. The fields are given as alternating keys and values
+// and are encoded once, here. The child shares the writers and the level with the logger.
+//
+// Example:
+//
+//	billingLogger := logger.With("service", "billing", "shard", 3)
+//	billingLogger.Info("started") // 2023/10/01 12:00:00 service=billing shard=3 started
+func (logger *StandardLogger) With(keysAndValues ...interface{}) *StandardLogger {
+	return logger.withFields(appendKeyValues(logger.fields, make([]byte, 0, 16*len(keysAndValues)), keysAndValues))
+}
+
+// withFields returns a child logger with the fields, encoded by the fieldEncoder of the logger, added to its contexts.
+func (logger *StandardLogger) withFields(fields []byte) *StandardLogger {
+	child := *logger
+	child.contexts = make([][]byte, len(logger.encoders))
+	for i, context := range logger.contexts {
+		child.contexts[i] = make([]byte, 0, len(context)+len(fields))
+		child.contexts[i] = append(child.contexts[i], context...)
+		if len(logger.encoders) == 1 {
+			child.contexts[i] = append(child.contexts[i], fields...)
+		} else {
+			child.contexts[i] = extractFields(child.contexts[i], fields, i, len(logger.encoders))
+		}
+	}
+	return &child
+}
+
+// addEncoder adds the encoder to the encoders if it is not there yet and returns its index. A nil encoder is
+// the default one.
+func (logger *StandardLogger) addEncoder(encoder Encoder) int {
+	if encoder == nil {
+		return 0
+	}
+	for i, e := range logger.encoders {
+		if reflect.TypeOf(e) == reflect.TypeOf(encoder) && reflect.TypeOf(e).Comparable() && e == encoder {
+			return i
+		}
+	}
+	logger.encoders = append(logger.encoders, encoder)
+	return len(logger.encoders) - 1
+}
+
+// fieldEncoder returns the encoder of the fields of the logs.
+func (logger *StandardLogger) fieldEncoder() Encoder {
+	return logger.fields
+}
+
+// writerEncoder returns the index of the encoder of the writer of the level in encoders.
+func (logger *StandardLogger) writerEncoder(level Level) int {
+	if level == levelRecord {
+		return logger.recordEncoder
+	}
+	return logger.levelEncoders[level]
+}
+
+// isConsoleSeparate reports whether the console encodes the logs of the level with another encoder than the writer.
+func (logger *StandardLogger) isConsoleSeparate(level Level) bool {
+	return logger.console != nil && logger.consoleEncoder != logger.writerEncoder(level)
+}
+
+// logLevel writes the buf to the console, unless the console encodes the logs of the level separately,
+// and to the writer.
+func (logger *StandardLogger) logLevel(level Level, buf []byte, writer io.Writer) {
+	if logger.console != nil && !logger.isConsoleSeparate(level) {
+		logger.writeConsole(level, buf)
+	}
+	if writer != nil {
+		writer.Write(buf)
+	}
+}
+
+// writeConsole writes the buf to the console in the color of the level if the console is colored.
+func (logger *StandardLogger) writeConsole(level Level, buf []byte) {
+	if logger.isColoredConsole {
+		logger.console.Write(appendColored(make([]byte, 0, coloredSize(buf)), level, buf))
+	} else {
+		logger.console.Write(buf)
+	}
+}
+
+// writeConsoleSpans writes the buf with the logs of the levels in the spans to the console.
+func (logger *StandardLogger) writeConsoleSpans(buf []byte, spans []levelSpan) {
+	if !logger.isColoredConsole {
+		logger.console.Write(buf)
+		return
+	}
+	colored := make([]byte, 0, coloredSize(buf))
+	start := 0
+	for _, span := range spans {
+		colored = appendColored(colored, span.level, buf[start:span.end])
+		start = span.end
+	}
+	logger.console.Write(colored)
+}
+
 func (logger *StandardLogger) log(buf []byte, writer io.Writer) {
 	if logger.console != nil {
 		logger.console.Write(buf)
@@ -123,36 +385,189 @@ func (logger *StandardLogger) log(buf []byte, writer io.Writer) {
 	}
 }
 
-func (logger *StandardLogger) info(buf []byte) {
-	logger.log(buf, logger.infoWriter)
+// writer returns the writer of the level.
+func (logger *StandardLogger) writer(level Level) io.Writer {
+	switch level {
+	case LevelTrace:
+		return logger.traceWriter
+	case LevelDebug:
+		return logger.debugWriter
+	case LevelInfo:
+		return logger.infoWriter
+	case LevelSuccess:
+		return logger.successWriter
+	case LevelWarning:
+		return logger.warningWriter
+	case LevelError:
+		return logger.errorWriter
+	case levelRecord:
+		return logger.recordWriter
+	case levelRaw:
+		return logger.rawWriter
+	default:
+		return logger.fatalWriter
+	}
 }
 
-func (logger *StandardLogger) error(buf []byte) {
-	logger.log(buf, logger.errorWriter)
+// write writes the buffered logs of the level, which may be levelRaw, to the writer of the level and to the console,
+// unless FastLogger buffers the logs of the console separately.
+func (logger *StandardLogger) write(level Level, buf []byte) {
+	if logger.console != nil && !logger.separateConsole {
+		logger.writeConsole(level, buf)
+	}
+	if writer := logger.writer(level); writer != nil {
+		writer.Write(buf)
+	}
 }
 
-func (logger *StandardLogger) warning(buf []byte) {
-	logger.log(buf, logger.warningWriter)
+// writeUnified writes the buf with the logs of the levels in the spans to the console, unless FastLogger buffers
+// the logs of the console separately, and to the unified writer.
+func (logger *StandardLogger) writeUnified(buf []byte, spans []levelSpan) {
+	if logger.console != nil && !logger.separateConsole {
+		logger.writeConsoleSpans(buf, spans)
+	}
+	logger.unifiedWriter.Write(buf)
 }
 
-func (logger *StandardLogger) success(buf []byte) {
-	logger.log(buf, logger.successWriter)
+func (logger *StandardLogger) raw(buf []byte) {
+	logger.log(buf, logger.rawWriter)
 }
 
-func (logger *StandardLogger) fatal(buf []byte) {
-	logger.log(buf, logger.fatalWriter)
-	os.Exit(1)
+// label returns the label of the level, or nil if the level has no label.
+func (logger *StandardLogger) label(level Level) []byte {
+	if level < LevelTrace || level > LevelFatal {
+		return nil
+	}
+	return logger.labels[level]
 }
 
-func (logger *StandardLogger) record(buf []byte) {
-	logger.log(buf, logger.recordWriter)
+// now returns the time of the clock with its date in the timeFormat, or nil if the clock has not ticked yet.
+// The date is formatted once per tick of the clock.
+func (logger *StandardLogger) now() *timestamp {
+	now := clock.Load()
+	if now == nil || logger.timeFormat == TimeFormatDefault {
+		return now
+	}
+	if cached := logger.dates.Load(); cached != nil && cached.time.Equal(now.time) {
+		return cached
+	}
+	date := &timestamp{time: now.time, date: appendFormattedTime(nil, now.time, logger.timeFormat)}
+	logger.dates.Store(date)
+	return date
 }
 
-func (logger *StandardLogger) raw(buf []byte) {
-	logger.log(buf, logger.rawWriter)
+// newEntry returns the entry of the log with the time and the caller, without the context and the fields. The entry
+// is dated with the time t if it is not zero, else with the time of the call or of the clock. The date is appended
+// to the date record, which must outlive the entry.
+func (logger *StandardLogger) newEntry(level Level, showDate bool, t time.Time, prefix, msg []byte, date *Record) Entry {
+	entry := Entry{
+		Level:   level,
+		Label:   logger.label(level),
+		Prefix:  prefix,
+		Message: msg,
+	}
+	if logger.showCaller {
+		entry.Caller = caller()
+	}
+	switch {
+	case !showDate:
+	case !t.IsZero() || logger.isPreciseTime:
+		if t.IsZero() {
+			t = time.Now()
+		}
+		entry.Time = t
+		if logger.isPreciseTime {
+			date.rec = logger.appendPreciseDate(date.rec[:0], t)
+		} else {
+			date.rec = appendFormattedTime(date.rec[:0], t, logger.timeFormat)
+		}
+		entry.Date = date.rec
+	default:
+		if now := logger.now(); now != nil {
+			entry.Time = now.time
+			entry.Date = now.date
+		}
+	}
+	return entry
+}
+
+// appendEntry appends the entry with the fields, encoded by the fieldEncoder, encoded by the encoder with the index i
+// to dst. The trailing newline is dropped if isNewLine is false.
+func (logger *StandardLogger) appendEntry(dst []byte, i int, entry Entry, fields []byte, isNewLine bool) []byte {
+	entry.Context = logger.contexts[i]
+	if len(logger.encoders) == 1 {
+		entry.Fields = fields
+		dst = logger.encoders[0].AppendEntry(dst, entry)
+	} else {
+		scratch := Builder()
+		scratch.rec = extractFields(scratch.rec, fields, i, len(logger.encoders))
+		entry.Fields = scratch.rec
+		dst = logger.encoders[i].AppendEntry(dst, entry)
+		scratch.release()
+	}
+	if !isNewLine && len(dst) > 0 && dst[len(dst)-1] == '\n' {
+		dst = dst[:len(dst)-1]
+	}
+	return dst
+}
+
+// writeEntry encodes the entry with the fields by the encoders of the console and of the writer of the level,
+// once per distinct encoder, and writes it to the console and to the writer.
+func (logger *StandardLogger) writeEntry(level Level, entry Entry, fields []byte, isNewLine bool, writer io.Writer) {
+	size := 70 + len(entry.Prefix) + len(entry.Message) + len(fields)
+	if logger.isConsoleSeparate(level) {
+		buf := make([]byte, 0, size+len(logger.contexts[logger.consoleEncoder]))
+		logger.writeConsole(level, logger.appendEntry(buf, logger.consoleEncoder, entry, fields, isNewLine))
+		if writer == nil {
+			return
+		}
+	}
+	i := logger.writerEncoder(level)
+	buf := make([]byte, 0, size+len(logger.contexts[i]))
+	logger.logLevel(level, logger.appendEntry(buf, i, entry, fields, isNewLine), writer)
+}
+
+// logEntry logs the message and the encoded fields with the level. It exits the program if the level is LevelFatal.
+func (logger *StandardLogger) logEntry(level Level, msg, fields []byte) {
+	logger.logEntryAt(level, time.Time{}, msg, fields)
+}
+
+// logEntryAt logs the message and the encoded fields with the level, dated with the time t instead of the time of the
+// clock if t is not zero. It exits the program if the level is LevelFatal.
+func (logger *StandardLogger) logEntryAt(level Level, t time.Time, msg, fields []byte) {
+	date := Builder()
+	entry := logger.newEntry(level, logger.showDate, t, nil, msg, date)
+	logger.writeEntry(level, entry, fields, true, logger.writer(level))
+	date.release()
+	if level == LevelFatal {
+		os.Exit(1)
+	}
+}
+
+// logFormatted logs the formatted message with the level. The newline the encoder ends the log with is dropped, so the
+// log ends with a newline only if the message does. It exits the program if the level is LevelFatal.
+func (logger *StandardLogger) logFormatted(level Level, msg []byte) {
+	date := Builder()
+	entry := logger.newEntry(level, logger.showDate, time.Time{}, nil, msg, date)
+	logger.writeEntry(level, entry, nil, false, logger.writer(level))
+	date.release()
+	if level == LevelFatal {
+		os.Exit(1)
+	}
+}
+
+// logRecord logs the built or prepared record with the level. It exits the program if the level is LevelFatal.
+func (logger *StandardLogger) logRecord(level Level, record *Record) {
+	date := Builder()
+	entry := logger.newEntry(level, record.isShowDate, time.Time{}, record.prefix, record.rec, date)
+	logger.writeEntry(level, entry, nil, record.isNewLine, logger.writer(level))
+	date.release()
+	if level == LevelFatal {
+		os.Exit(1)
+	}
 }
 
-// Raw logs a raw log to the logger.rawWriter.
+// Raw logs a raw log to the logger.rawWriter. Raw logs are written as is, without encoding.
 func (logger *StandardLogger) Raw(record []byte) {
 	logger.raw(record)
 }
@@ -164,173 +579,336 @@ func (logger *StandardLogger) RawWithWriter(record []byte, writer io.Writer) {
 
 // Record logs a record to the logger.recordWriter. You can create a record with Builder(). Will reset the record.
 func (logger *StandardLogger) Record(record *Record) {
-	logger.record(record.rec)
-	record.Reset()
-	if record.wasGot {
-		recordPool.Put(record)
-	}
+	logger.logRecord(levelRecord, record)
+	record.release()
 }
 
-// RecordWithWriter logs a record to the writer. You can create a record with Builder().
+// RecordWithWriter logs a record to the writer. You can create a record with Builder(). The record is a copy that
+// shares its buffer with the caller's record, so it is neither reset nor put back to the pool and can be logged again.
 func (logger *StandardLogger) RecordWithWriter(record Record, writer io.Writer) {
-	logger.log(record.rec, writer)
-	record.Reset()
-	if record.wasGot {
-		recordPool.Put(record)
+	date := Builder()
+	entry := logger.newEntry(levelRecord, record.isShowDate, time.Time{}, record.prefix, record.rec, date)
+	logger.writeEntry(levelRecord, entry, nil, record.isNewLine, writer)
+	date.release()
+}
+
+// Trace logs a message to the logger.traceWriter.
+func (logger *StandardLogger) Trace(args ...interface{}) {
+	if !logger.isEnabled(LevelTrace) {
+		return
+	}
+	logArgs(logger, LevelTrace, args)
+}
+
+// FormatTrace logs a message with format to the logger.traceWriter.
+func (logger *StandardLogger) FormatTrace(f string, args ...interface{}) {
+	if !logger.isEnabled(LevelTrace) {
+		return
+	}
+	logFormat(logger, LevelTrace, f, args)
+}
+
+// TraceKV logs a message with fields to the logger.traceWriter. The fields are given as alternating keys and values.
+func (logger *StandardLogger) TraceKV(msg string, keysAndValues ...interface{}) {
+	if !logger.isEnabled(LevelTrace) {
+		return
+	}
+	logKV(logger, LevelTrace, msg, keysAndValues)
+}
+
+// TraceCtx logs a message with the fields of the registered context keys to the logger.traceWriter.
+func (logger *StandardLogger) TraceCtx(ctx context.Context, args ...interface{}) {
+	if !logger.isEnabled(LevelTrace) {
+		return
+	}
+	logCtx(logger, LevelTrace, ctx, args)
+}
+
+// TraceEvent returns an Event that will be written to the logger.traceWriter. It returns nil if LevelTrace is disabled.
+func (logger *StandardLogger) TraceEvent() *Event {
+	if !logger.isEnabled(LevelTrace) {
+		return nil
 	}
+	return newEvent(LevelTrace, logger)
+}
+
+// TracePrepare logs a prepared record to the logger.traceWriter. Will not reset the record.
+func (logger *StandardLogger) TracePrepare(record *Record) {
+	if !logger.isEnabled(LevelTrace) {
+		return
+	}
+	logger.logRecord(LevelTrace, record)
+}
+
+// Debug logs a message to the logger.debugWriter.
+func (logger *StandardLogger) Debug(args ...interface{}) {
+	if !logger.isEnabled(LevelDebug) {
+		return
+	}
+	logArgs(logger, LevelDebug, args)
+}
+
+// FormatDebug logs a message with format to the logger.debugWriter.
+func (logger *StandardLogger) FormatDebug(f string, args ...interface{}) {
+	if !logger.isEnabled(LevelDebug) {
+		return
+	}
+	logFormat(logger, LevelDebug, f, args)
+}
+
+// DebugKV logs a message with fields to the logger.debugWriter. The fields are given as alternating keys and values.
+func (logger *StandardLogger) DebugKV(msg string, keysAndValues ...interface{}) {
+	if !logger.isEnabled(LevelDebug) {
+		return
+	}
+	logKV(logger, LevelDebug, msg, keysAndValues)
+}
+
+// DebugCtx logs a message with the fields of the registered context keys to the logger.debugWriter.
+func (logger *StandardLogger) DebugCtx(ctx context.Context, args ...interface{}) {
+	if !logger.isEnabled(LevelDebug) {
+		return
+	}
+	logCtx(logger, LevelDebug, ctx, args)
+}
+
+// DebugEvent returns an Event that will be written to the logger.debugWriter. It returns nil if LevelDebug is disabled.
+func (logger *StandardLogger) DebugEvent() *Event {
+	if !logger.isEnabled(LevelDebug) {
+		return nil
+	}
+	return newEvent(LevelDebug, logger)
+}
+
+// DebugPrepare logs a prepared record to the logger.debugWriter. Will not reset the record.
+func (logger *StandardLogger) DebugPrepare(record *Record) {
+	if !logger.isEnabled(LevelDebug) {
+		return
+	}
+	logger.logRecord(LevelDebug, record)
 }
 
 // Info logs a message to the logger.infoWriter.
 func (logger *StandardLogger) Info(args ...interface{}) {
-	buf := make([]byte, 0, 70)
-	if logger.showDate {
-		buf = append(Now.Load().([]byte), buf...)
+	if !logger.isEnabled(LevelInfo) {
+		return
 	}
-	buf = addArgsToLog(buf, args...)
-	logger.info(append(buf, '\n'))
-	buf = nil
+	logArgs(logger, LevelInfo, args)
 }
 
 // FormatInfo logs a message with format to the logger.infoWriter.
 func (logger *StandardLogger) FormatInfo(f string, args ...interface{}) {
-	if logger.showDate {
-		buf := make([]byte, 0, 70)
-		buf = append(Now.Load().([]byte), buf...)
-		buf = append(buf, fastbytes.S2B(fmt.Sprintf(f, args...))...)
-		logger.info(buf)
-	} else {
-		logger.info(fastbytes.S2B(fmt.Sprintf(f, args...)))
+	if !logger.isEnabled(LevelInfo) {
+		return
+	}
+	logFormat(logger, LevelInfo, f, args)
+}
+
+// InfoKV logs a message with fields to the logger.infoWriter. The fields are given as alternating keys and values.
+func (logger *StandardLogger) InfoKV(msg string, keysAndValues ...interface{}) {
+	if !logger.isEnabled(LevelInfo) {
+		return
+	}
+	logKV(logger, LevelInfo, msg, keysAndValues)
+}
+
+// InfoCtx logs a message with the fields of the registered context keys to the logger.infoWriter.
+func (logger *StandardLogger) InfoCtx(ctx context.Context, args ...interface{}) {
+	if !logger.isEnabled(LevelInfo) {
+		return
 	}
+	logCtx(logger, LevelInfo, ctx, args)
+}
+
+// InfoEvent returns an Event that will be written to the logger.infoWriter. It returns nil if LevelInfo is disabled.
+func (logger *StandardLogger) InfoEvent() *Event {
+	if !logger.isEnabled(LevelInfo) {
+		return nil
+	}
+	return newEvent(LevelInfo, logger)
 }
 
 // InfoPrepare logs a prepared record to the logger.infoWriter. Will not reset the record.
 func (logger *StandardLogger) InfoPrepare(record *Record) {
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.isEnabled(LevelInfo) {
+		return
 	}
-	logger.info(record.rec)
+	logger.logRecord(LevelInfo, record)
 }
 
 // Error logs a message to the logger.errorWriter.
 func (logger *StandardLogger) Error(args ...interface{}) {
-	buf := make([]byte, 0, 70)
-	if logger.showDate {
-		buf = append(Now.Load().([]byte), buf...)
+	if !logger.isEnabled(LevelError) {
+		return
 	}
-	buf = addArgsToLog(buf, args...)
-	logger.error(append(buf, '\n'))
-	buf = nil
+	logArgs(logger, LevelError, args)
 }
 
 // FormatError logs a message with format to the logger.errorWriter.
 func (logger *StandardLogger) FormatError(f string, args ...interface{}) {
-	if logger.showDate {
-		buf := make([]byte, 0, 70)
-		buf = append(Now.Load().([]byte), buf...)
-		buf = append(buf, fastbytes.S2B(fmt.Sprintf(f, args...))...)
-		logger.error(buf)
-	} else {
-		logger.error(fastbytes.S2B(fmt.Sprintf(f, args...)))
+	if !logger.isEnabled(LevelError) {
+		return
+	}
+	logFormat(logger, LevelError, f, args)
+}
+
+// ErrorKV logs a message with fields to the logger.errorWriter. The fields are given as alternating keys and values.
+func (logger *StandardLogger) ErrorKV(msg string, keysAndValues ...interface{}) {
+	if !logger.isEnabled(LevelError) {
+		return
+	}
+	logKV(logger, LevelError, msg, keysAndValues)
+}
+
+// ErrorCtx logs a message with the fields of the registered context keys to the logger.errorWriter.
+func (logger *StandardLogger) ErrorCtx(ctx context.Context, args ...interface{}) {
+	if !logger.isEnabled(LevelError) {
+		return
+	}
+	logCtx(logger, LevelError, ctx, args)
+}
+
+// ErrorEvent returns an Event that will be written to the logger.errorWriter. It returns nil if LevelError is disabled.
+func (logger *StandardLogger) ErrorEvent() *Event {
+	if !logger.isEnabled(LevelError) {
+		return nil
 	}
+	return newEvent(LevelError, logger)
 }
 
 // ErrorPrepare logs a prepared record to the logger.errorWriter. Will not reset the record.
 func (logger *StandardLogger) ErrorPrepare(record *Record) {
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.isEnabled(LevelError) {
+		return
 	}
-	logger.error(record.rec)
+	logger.logRecord(LevelError, record)
 }
 
 // Warning logs a message to the logger.warningWriter.
 func (logger *StandardLogger) Warning(args ...interface{}) {
-	buf := make([]byte, 0, 70)
-	if logger.showDate {
-		buf = append(Now.Load().([]byte), buf...)
+	if !logger.isEnabled(LevelWarning) {
+		return
 	}
-	buf = addArgsToLog(buf, args...)
-	logger.warning(append(buf, '\n'))
-	buf = nil
+	logArgs(logger, LevelWarning, args)
 }
 
 // FormatWarning logs a message with format to the logger.warningWriter.
 func (logger *StandardLogger) FormatWarning(f string, args ...interface{}) {
-	if logger.showDate {
-		buf := make([]byte, 0, 70)
-		buf = append(Now.Load().([]byte), buf...)
-		buf = append(buf, fastbytes.S2B(fmt.Sprintf(f, args...))...)
-		logger.warning(buf)
-	} else {
-		logger.warning(fastbytes.S2B(fmt.Sprintf(f, args...)))
+	if !logger.isEnabled(LevelWarning) {
+		return
+	}
+	logFormat(logger, LevelWarning, f, args)
+}
+
+// WarningKV logs a message with fields to the logger.warningWriter. The fields are given as alternating keys
+// and values.
+func (logger *StandardLogger) WarningKV(msg string, keysAndValues ...interface{}) {
+	if !logger.isEnabled(LevelWarning) {
+		return
 	}
+	logKV(logger, LevelWarning, msg, keysAndValues)
+}
+
+// WarningCtx logs a message with the fields of the registered context keys to the logger.warningWriter.
+func (logger *StandardLogger) WarningCtx(ctx context.Context, args ...interface{}) {
+	if !logger.isEnabled(LevelWarning) {
+		return
+	}
+	logCtx(logger, LevelWarning, ctx, args)
+}
+
+// WarningEvent returns an Event that will be written to the logger.warningWriter. It returns nil if LevelWarning is disabled.
+func (logger *StandardLogger) WarningEvent() *Event {
+	if !logger.isEnabled(LevelWarning) {
+		return nil
+	}
+	return newEvent(LevelWarning, logger)
 }
 
 // WarningPrepare logs a prepared record to the logger.warningWriter. Will not reset the record.
 func (logger *StandardLogger) WarningPrepare(record *Record) {
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.isEnabled(LevelWarning) {
+		return
 	}
-	logger.warning(record.rec)
+	logger.logRecord(LevelWarning, record)
 }
 
 // Success logs a message to the logger.successWriter.
 func (logger *StandardLogger) Success(args ...interface{}) {
-	buf := make([]byte, 0, 70)
-	if logger.showDate {
-		buf = append(Now.Load().([]byte), buf...)
+	if !logger.isEnabled(LevelSuccess) {
+		return
 	}
-	buf = addArgsToLog(buf, args...)
-	logger.success(append(buf, '\n'))
-	buf = nil
+	logArgs(logger, LevelSuccess, args)
 }
 
 // FormatSuccess logs a message with format to the logger.successWriter.
 func (logger *StandardLogger) FormatSuccess(f string, args ...interface{}) {
-	if logger.showDate {
-		buf := make([]byte, 0, 70)
-		buf = append(Now.Load().([]byte), buf...)
-		buf = append(buf, fastbytes.S2B(fmt.Sprintf(f, args...))...)
-		logger.success(buf)
-	} else {
-		logger.success(fastbytes.S2B(fmt.Sprintf(f, args...)))
+	if !logger.isEnabled(LevelSuccess) {
+		return
+	}
+	logFormat(logger, LevelSuccess, f, args)
+}
+
+// SuccessKV logs a message with fields to the logger.successWriter. The fields are given as alternating keys
+// and values.
+func (logger *StandardLogger) SuccessKV(msg string, keysAndValues ...interface{}) {
+	if !logger.isEnabled(LevelSuccess) {
+		return
+	}
+	logKV(logger, LevelSuccess, msg, keysAndValues)
+}
+
+// SuccessCtx logs a message with the fields of the registered context keys to the logger.successWriter.
+func (logger *StandardLogger) SuccessCtx(ctx context.Context, args ...interface{}) {
+	if !logger.isEnabled(LevelSuccess) {
+		return
+	}
+	logCtx(logger, LevelSuccess, ctx, args)
+}
+
+// SuccessEvent returns an Event that will be written to the logger.successWriter. It returns nil if LevelSuccess is disabled.
+func (logger *StandardLogger) SuccessEvent() *Event {
+	if !logger.isEnabled(LevelSuccess) {
+		return nil
 	}
+	return newEvent(LevelSuccess, logger)
 }
 
 // SuccessPrepare logs a prepared record to the logger.successWriter. Will not reset the record.
 func (logger *StandardLogger) SuccessPrepare(record *Record) {
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
+	if !logger.isEnabled(LevelSuccess) {
+		return
 	}
-	logger.success(record.rec)
+	logger.logRecord(LevelSuccess, record)
 }
 
 // Fatal logs a message to the logger.fatalWriter. It will exit the program.
 func (logger *StandardLogger) Fatal(args ...interface{}) {
-	buf := make([]byte, 0, 70)
-	if logger.showDate {
-		buf = append(Now.Load().([]byte), buf...)
-	}
-	buf = addArgsToLog(buf, args...)
-	logger.fatal(append(buf, '\n'))
-	buf = nil
+	logArgs(logger, LevelFatal, args)
 }
 
 // FormatFatal logs a message with format to the logger.fatalWriter. It will exit the program.
 func (logger *StandardLogger) FormatFatal(f string, args ...interface{}) {
-	if logger.showDate {
-		buf := make([]byte, 0, 70)
-		buf = append(Now.Load().([]byte), buf...)
-		buf = append(buf, fastbytes.S2B(fmt.Sprintf(f, args...))...)
-		logger.fatal(buf)
-	} else {
-		logger.fatal(fastbytes.S2B(fmt.Sprintf(f, args...)))
-	}
+	logFormat(logger, LevelFatal, f, args)
+}
+
+// FatalKV logs a message with fields to the logger.fatalWriter. It will exit the program.
+func (logger *StandardLogger) FatalKV(msg string, keysAndValues ...interface{}) {
+	logKV(logger, LevelFatal, msg, keysAndValues)
+}
+
+// FatalCtx logs a message with the fields of the registered context keys to the logger.fatalWriter. It will exit the
+// program.
+func (logger *StandardLogger) FatalCtx(ctx context.Context, args ...interface{}) {
+	logCtx(logger, LevelFatal, ctx, args)
+}
+
+// FatalEvent returns an Event that will be written to the logger.fatalWriter. The program exits when the event is written.
+func (logger *StandardLogger) FatalEvent() *Event {
+	return newEvent(LevelFatal, logger)
 }
 
 // FatalPrepare logs a prepared record to the logger.fatalWriter. Will not reset the record.
 func (logger *StandardLogger) FatalPrepare(record *Record) {
-	if record.isShowDate {
-		copy(record.rec[:19], Now.Load().([]byte))
-	}
-	logger.fatal(record.rec)
+	logger.logRecord(LevelFatal, record)
 }
@@ -0,0 +1,278 @@
+package logger_test
+
+import (
+	"bytes"
+	"github.com/Eugene-Usachev/logger"
+	"sync"
+	"testing"
+	"time"
+)
+
+// levelWriters are the writers of the levels of a logger.
+type levelWriters struct {
+	trace, debug, info, success, warning, error bytes.Buffer
+}
+
+func (w *levelWriters) config() logger.StandardLoggerConfig {
+	return logger.StandardLoggerConfig{
+		TraceWriter:   &w.trace,
+		DebugWriter:   &w.debug,
+		InfoWriter:    &w.info,
+		SuccessWriter: &w.success,
+		WarningWriter: &w.warning,
+		ErrorWriter:   &w.error,
+	}
+}
+
+// levelLogger is the part of StandardLogger and FastLogger checked by the tests of the levels.
+type levelLogger interface {
+	Trace(args ...interface{})
+	Debug(args ...interface{})
+	Info(args ...interface{})
+	Success(args ...interface{})
+	Warning(args ...interface{})
+	Error(args ...interface{})
+}
+
+func logAllLevels(log levelLogger) {
+	log.Trace("trace")
+	log.Debug("debug")
+	log.Info("info")
+	log.Success("success")
+	log.Warning("warning")
+	log.Error("error")
+}
+
+func checkLevelWriters(t *testing.T, w *levelWriters, want map[string]string) {
+	t.Helper()
+	got := map[string]string{
+		"trace":   w.trace.String(),
+		"debug":   w.debug.String(),
+		"info":    w.info.String(),
+		"success": w.success.String(),
+		"warning": w.warning.String(),
+		"error":   w.error.String(),
+	}
+	for writer, wantLogs := range want {
+		if got[writer] != wantLogs {
+			t.Errorf("the %s writer got %q, want %q", writer, got[writer], wantLogs)
+		}
+	}
+}
+
+func TestLevelWriters(t *testing.T) {
+	want := map[string]string{
+		"trace":   "trace\n",
+		"debug":   "debug\n",
+		"info":    "info\n",
+		"success": "success\n",
+		"warning": "warning\n",
+		"error":   "error\n",
+	}
+
+	var std levelWriters
+	cfg := std.config()
+	logAllLevels(logger.NewStandardLogger(&cfg))
+	checkLevelWriters(t, &std, want)
+
+	var fast levelWriters
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{
+		StandardLoggerConfig: fast.config(),
+		FlushInterval:        time.Hour,
+	})
+	defer fastLogger.Stop()
+	logAllLevels(fastLogger)
+	fastLogger.Flush()
+	checkLevelWriters(t, &fast, want)
+}
+
+func TestLevelFiltering(t *testing.T) {
+	tests := []struct {
+		level logger.Level
+		want  map[string]string
+	}{
+		{logger.LevelTrace, map[string]string{"trace": "trace\n", "info": "info\n", "error": "error\n"}},
+		{logger.LevelInfo, map[string]string{"trace": "", "debug": "", "info": "info\n", "error": "error\n"}},
+		{logger.LevelWarning, map[string]string{"success": "", "warning": "warning\n", "error": "error\n"}},
+		{logger.LevelFatal, map[string]string{"info": "", "warning": "", "error": ""}},
+	}
+	for _, test := range tests {
+		var w levelWriters
+		cfg := w.config()
+		cfg.Level = test.level
+		log := logger.NewStandardLogger(&cfg)
+		logAllLevels(log)
+		checkLevelWriters(t, &w, test.want)
+		if log.Level() != test.level {
+			t.Errorf("Level() = %v, want %v", log.Level(), test.level)
+		}
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	cfg.Level = logger.LevelError
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: cfg, FlushInterval: time.Hour})
+	defer fastLogger.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				fastLogger.Info("info")
+				fastLogger.Level()
+			}
+		}()
+	}
+	fastLogger.SetLevel(logger.LevelInfo)
+	wg.Wait()
+	fastLogger.Flush()
+	if fastLogger.Level() != logger.LevelInfo {
+		t.Errorf("Level() = %v, want info", fastLogger.Level())
+	}
+
+	w.info.Reset()
+	fastLogger.Info("info")
+	fastLogger.SetLevel(logger.LevelWarning)
+	fastLogger.Info("dropped")
+	fastLogger.Flush()
+	checkLevelWriters(t, &w, map[string]string{"info": "info\n"})
+}
+
+func TestWith(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	parent := logger.NewStandardLogger(&cfg)
+	child := parent.With("service", "billing")
+	grandchild := child.With("shard", 3)
+	sibling := parent.With("service", "orders")
+
+	parent.Info("parent")
+	child.Info("child")
+	grandchild.Info("grandchild")
+	sibling.Info("sibling")
+	child.Info("child again")
+	checkLevelWriters(t, &w, map[string]string{
+		"info": "parent\n" +
+			"service=billing child\n" +
+			"service=billing shard=3 grandchild\n" +
+			"service=orders sibling\n" +
+			"service=billing child again\n",
+	})
+}
+
+func TestFastLoggerWith(t *testing.T) {
+	var w levelWriters
+	parent := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: w.config(), FlushInterval: time.Hour})
+	defer parent.Stop()
+	child := parent.With("request_id", 42)
+	child.Info("child")
+	parent.Info("parent")
+	logger.With(child, "user", "bob").Error("generic")
+	parent.Flush()
+	checkLevelWriters(t, &w, map[string]string{
+		"info":  "request_id=42 child\nparent\n",
+		"error": "request_id=42 user=bob generic\n",
+	})
+	if got := logger.With(logger.NopLogger{}, "user", "bob"); got != (logger.NopLogger{}) {
+		t.Errorf("With(NopLogger{}) = %#v, want NopLogger{}", got)
+	}
+}
+
+func TestKV(t *testing.T) {
+	tests := []struct {
+		keysAndValues []interface{}
+		want          string
+	}{
+		{nil, "order created\n"},
+		{[]interface{}{"id", 42, "took", 12 * time.Millisecond}, "order created id=42 took=12ms\n"},
+		{[]interface{}{"user", "bob smith", "note", `say "hi"`}, `order created user="bob smith" note="say \"hi\""` + "\n"},
+		{[]interface{}{"id", 42, "orphan"}, "order created id=42 !BADKEY=orphan\n"},
+		{[]interface{}{7, true}, "order created 7=true\n"},
+	}
+	for _, test := range tests {
+		var w levelWriters
+		cfg := w.config()
+		logger.NewStandardLogger(&cfg).InfoKV("order created", test.keysAndValues...)
+		if got := w.info.String(); got != test.want {
+			t.Errorf("InfoKV(%v) = %q, want %q", test.keysAndValues, got, test.want)
+		}
+	}
+}
+
+func TestRecordWithWriterKeepsRecord(t *testing.T) {
+	log := logger.NewStandardLogger(&logger.StandardLoggerConfig{})
+	record := logger.Builder().NoDate().AppendArgs("hello world, the record outlives its logs").Build()
+	want := "hello world, the record outlives its logs\n"
+	for i := 0; i < 2; i++ {
+		var buf bytes.Buffer
+		log.RecordWithWriter(*record, &buf)
+		if buf.String() != want {
+			t.Fatalf("log %d of the record = %q, want %q", i, buf.String(), want)
+		}
+		for j := 0; j < 10; j++ {
+			logger.Builder().NoDate().AppendArgs("XXXXXXXX").Build()
+		}
+	}
+}
+
+func TestLevelLabels(t *testing.T) {
+	tests := []struct {
+		encoder logger.Encoder
+		labels  map[logger.Level]string
+		want    string
+	}{
+		{logger.NewTextEncoder(), nil, "order created id=42\n"},
+		{logger.NewTextEncoder(), logger.DefaultLevelLabels, "WARN order created id=42\n"},
+		{logger.NewTextEncoder(), map[logger.Level]string{logger.LevelError: "ERR"}, "order created id=42\n"},
+		{logger.NewJSONEncoder(), nil, `{"level":"warning","msg":"order created","id":42}` + "\n"},
+		{logger.NewJSONEncoder(), logger.DefaultLevelLabels, `{"level":"WARN","msg":"order created","id":42}` + "\n"},
+		{logger.NewLogfmtEncoder(), map[logger.Level]string{logger.LevelWarning: "W A"},
+			`level="W A" msg="order created" id=42` + "\n"},
+	}
+	for _, test := range tests {
+		var w levelWriters
+		cfg := w.config()
+		cfg.Encoder = test.encoder
+		cfg.LevelLabels = test.labels
+		logger.NewStandardLogger(&cfg).WarningKV("order created", "id", 42)
+		if got := w.warning.String(); got != test.want {
+			t.Errorf("WarningKV() with %T and the labels %v = %q, want %q", test.encoder, test.labels, got, test.want)
+		}
+	}
+}
+
+func TestUnifiedWriter(t *testing.T) {
+	var unified, info bytes.Buffer
+	cfg := logger.StandardLoggerConfig{InfoWriter: &info, UnifiedWriter: &unified, LevelLabels: logger.DefaultLevelLabels}
+	want := "INFO first\nERROR second\n[record] third\nraw\nDEBUG fourth\n"
+
+	log := logger.NewStandardLogger(&cfg)
+	log.Info("first")
+	log.Error("second")
+	log.Record(logger.Builder().NoDate().Prefix("[record] ").AppendArgs("third").Build())
+	log.Raw([]byte("raw\n"))
+	log.Debug("fourth")
+	if unified.String() != want {
+		t.Errorf("StandardLogger wrote %q to the unified writer, want %q", unified.String(), want)
+	}
+
+	unified.Reset()
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: cfg, FlushInterval: time.Hour})
+	defer fastLogger.Stop()
+	fastLogger.Info("first")
+	fastLogger.Error("second")
+	fastLogger.Record(logger.Builder().NoDate().Prefix("[record] ").AppendArgs("third").Build())
+	fastLogger.Raw([]byte("raw\n"))
+	fastLogger.Debug("fourth")
+	fastLogger.Flush()
+	if unified.String() != want {
+		t.Errorf("FastLogger wrote %q to the unified writer, want %q", unified.String(), want)
+	}
+	if info.Len() != 0 {
+		t.Errorf("the info writer got %q in the unified mode, want nothing", info.String())
+	}
+}
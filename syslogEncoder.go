@@ -0,0 +1,278 @@
+package logger
+
+import (
+	"fmt"
+	"github.com/Eugene-Usachev/fastbytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// SyslogFormat is the format of syslog messages.
+type SyslogFormat uint8
+
+const (
+	// SyslogRFC5424 is the format of RFC 5424 with the fields as structured data.
+	SyslogRFC5424 SyslogFormat = iota
+	// SyslogRFC3164 is the BSD format of RFC 3164 with the fields as "key=value" pairs after the message.
+	SyslogRFC3164
+)
+
+// SyslogEncoderConfig is the configuration of a SyslogEncoder.
+type SyslogEncoderConfig struct {
+	// Format is the format of the messages. By default, it's SyslogRFC5424.
+	Format SyslogFormat
+	// Facility is the syslog facility, from 0 to 23. By default, it's 1 (user-level messages), so the kernel
+	// facility can't be used.
+	Facility int
+	// Hostname is the name of the host. By default, it's the name of the host reported by the kernel.
+	Hostname string
+	// AppName is the name of the application, the tag of RFC 3164. By default, it's the name of the executable.
+	AppName string
+	// MsgID is the MSGID of RFC 5424. By default, it's "-".
+	MsgID string
+	// StructuredDataID is the SD-ID of the structured data with the fields. By default, it's "fields@32473".
+	StructuredDataID string
+}
+
+// SyslogEncoder encodes entries into syslog messages, one per line:
+//
+//	<14>1 2023-10-01T12:00:00.000+02:00 api-1 billing 4242 - [fields@32473 id="42"] order created
+//	<14>Oct  1 12:00:00 api-1 billing[4242]: order created id=42
+//
+// The severity of the message is the syslog severity of the level: crit for fatal errors, err for errors, warning
+// for warnings, notice for successes, info for information and records and debug for debug and trace messages.
+// Logs without the date have no timestamp ("-" in RFC 5424). Newlines in the messages are escaped as "#012", so that
+// every message is one line. In RFC 5424, the caller and the fields are the parameters of structured data; names are
+// cut to 32 characters, and spaces, '=', ']' and '"' in them are replaced with '_'.
+//
+// Use a SyslogWriter to send the messages to a syslog daemon.
+type SyslogEncoder struct {
+	format   SyslogFormat
+	facility int
+	// header is the part of the header after the timestamp: the hostname, the app name, the process id and the msgid
+	// in RFC 5424, or the hostname and the tag with the process id in RFC 3164.
+	header []byte
+	// sdID is the beginning of the structured data.
+	sdID []byte
+}
+
+// NewSyslogEncoder creates a new SyslogEncoder.
+func NewSyslogEncoder(cfg *SyslogEncoderConfig) *SyslogEncoder {
+	enc := &SyslogEncoder{
+		format:   cfg.Format,
+		facility: cfg.Facility,
+	}
+	if enc.facility <= 0 || enc.facility > 23 {
+		enc.facility = 1
+	}
+	hostname := cfg.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+	appName := cfg.AppName
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+	pid := strconv.Itoa(os.Getpid())
+
+	if enc.format == SyslogRFC3164 {
+		enc.header = appendSyslogHeaderField(enc.header, hostname, 255)
+		enc.header = append(enc.header, ' ')
+		enc.header = appendSyslogHeaderField(enc.header, appName, 32)
+		enc.header = append(enc.header, '[')
+		enc.header = append(enc.header, pid...)
+		enc.header = append(enc.header, "]:"...)
+		return enc
+	}
+
+	enc.header = appendSyslogHeaderField(enc.header, hostname, 255)
+	enc.header = append(enc.header, ' ')
+	enc.header = appendSyslogHeaderField(enc.header, appName, 48)
+	enc.header = append(enc.header, ' ')
+	enc.header = append(enc.header, pid...)
+	enc.header = append(enc.header, ' ')
+	enc.header = appendSyslogHeaderField(enc.header, cfg.MsgID, 32)
+	sdID := cfg.StructuredDataID
+	if sdID == "" {
+		sdID = "fields@32473"
+	}
+	enc.sdID = append(enc.sdID, '[')
+	enc.sdID = appendSDName(enc.sdID, sdID)
+	return enc
+}
+
+// appendSyslogHeaderField appends the field of the header cut to the max length with the characters other than
+// printable ASCII replaced with '_'. An empty field is written as "-".
+func appendSyslogHeaderField(dst []byte, field string, max int) []byte {
+	if field == "" {
+		return append(dst, '-')
+	}
+	if len(field) > max {
+		field = field[:max]
+	}
+	for i := 0; i < len(field); i++ {
+		if field[i] <= ' ' || field[i] >= 0x7f {
+			dst = append(dst, '_')
+		} else {
+			dst = append(dst, field[i])
+		}
+	}
+	return dst
+}
+
+// appendSDName appends the name of a parameter or of structured data cut to 32 characters with the characters that
+// are not allowed replaced with '_'.
+func appendSDName(dst []byte, name string) []byte {
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c <= ' ' || c >= 0x7f || c == '=' || c == ']' || c == '"' {
+			dst = append(dst, '_')
+		} else {
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// appendSyslogText appends the text with newlines escaped as "#012" and carriage returns as "#015". If isParam is
+// true, '"', '\' and ']' are escaped with '\' for the value of a parameter of structured data.
+func appendSyslogText(dst []byte, s string, isParam bool) []byte {
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\n':
+			dst = append(dst, "#012"...)
+		case c == '\r':
+			dst = append(dst, "#015"...)
+		case isParam && (c == '"' || c == '\\' || c == ']'):
+			dst = append(dst, '\\', c)
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *SyslogEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	dst = append(dst, '<')
+	dst = strconv.AppendInt(dst, int64(enc.facility*8+entry.Level.syslogSeverity()), 10)
+	dst = append(dst, '>')
+	prefix, msg := fastbytes.B2S(entry.Prefix), fastbytes.B2S(entry.Message)
+
+	if enc.format == SyslogRFC3164 {
+		if entry.Date != nil {
+			dst = entry.Time.AppendFormat(dst, time.Stamp)
+			dst = append(dst, ' ')
+		}
+		dst = append(dst, enc.header...)
+		if len(prefix) > 0 || len(msg) > 0 {
+			dst = append(dst, ' ')
+			dst = appendSyslogText(dst, prefix, false)
+			dst = appendSyslogText(dst, msg, false)
+		}
+		if len(entry.Caller) > 0 {
+			dst = append(dst, " caller="...)
+			dst = appendString(dst, entry.Caller)
+		}
+		dst = append(dst, entry.Context...)
+		dst = append(dst, entry.Fields...)
+		return append(dst, '\n')
+	}
+
+	dst = append(dst, '1', ' ')
+	if entry.Date != nil {
+		dst = entry.Time.AppendFormat(dst, "2006-01-02T15:04:05.000Z07:00")
+	} else {
+		dst = append(dst, '-')
+	}
+	dst = append(dst, ' ')
+	dst = append(dst, enc.header...)
+	dst = append(dst, ' ')
+	if len(entry.Caller) > 0 || len(entry.Context) > 0 || len(entry.Fields) > 0 {
+		dst = append(dst, enc.sdID...)
+		if len(entry.Caller) > 0 {
+			dst = enc.AppendString(dst, "caller", entry.Caller)
+		}
+		dst = append(dst, entry.Context...)
+		dst = append(dst, entry.Fields...)
+		dst = append(dst, ']')
+	} else {
+		dst = append(dst, '-')
+	}
+	if len(prefix) > 0 || len(msg) > 0 {
+		dst = append(dst, ' ')
+		dst = appendSyslogText(dst, prefix, false)
+		dst = appendSyslogText(dst, msg, false)
+	}
+	return append(dst, '\n')
+}
+
+// AppendString appends the field as a parameter of structured data in RFC 5424 or as a "key=value" pair in RFC 3164.
+func (enc *SyslogEncoder) AppendString(dst []byte, key, value string) []byte {
+	if enc.format == SyslogRFC3164 {
+		return appendString(appendTextKey(dst, key), value)
+	}
+	dst = append(dst, ' ')
+	dst = appendSDName(dst, key)
+	dst = append(dst, '=', '"')
+	dst = appendSyslogText(dst, value, true)
+	return append(dst, '"')
+}
+
+// appendRaw appends the field with the value that needs no quoting or escaping.
+func (enc *SyslogEncoder) appendRaw(dst []byte, key string, value []byte) []byte {
+	if enc.format == SyslogRFC3164 {
+		return append(appendTextKey(dst, key), value...)
+	}
+	dst = append(dst, ' ')
+	dst = appendSDName(dst, key)
+	dst = append(dst, '=', '"')
+	dst = append(dst, value...)
+	return append(dst, '"')
+}
+
+func (enc *SyslogEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	var buf [20]byte
+	return enc.appendRaw(dst, key, strconv.AppendInt(buf[:0], value, 10))
+}
+
+func (enc *SyslogEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	var buf [20]byte
+	return enc.appendRaw(dst, key, strconv.AppendUint(buf[:0], value, 10))
+}
+
+func (enc *SyslogEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	var buf [32]byte
+	return enc.appendRaw(dst, key, strconv.AppendFloat(buf[:0], value, 'f', -1, 64))
+}
+
+func (enc *SyslogEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	var buf [5]byte
+	return enc.appendRaw(dst, key, strconv.AppendBool(buf[:0], value))
+}
+
+// AppendDuration appends the duration in milliseconds, like "1.5ms".
+func (enc *SyslogEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	var buf [32]byte
+	return enc.appendRaw(dst, key, appendDuration(buf[:0], value))
+}
+
+// AppendTime appends the time in RFC 3339 format.
+func (enc *SyslogEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	var buf [64]byte
+	return enc.appendRaw(dst, key, appendTime(buf[:0], value))
+}
+
+func (enc *SyslogEncoder) AppendError(dst []byte, key string, err error) []byte {
+	return enc.AppendString(dst, key, err.Error())
+}
+
+// AppendAny appends the value formatted by fmt.
+func (enc *SyslogEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	return enc.AppendString(dst, key, fmt.Sprint(value))
+}
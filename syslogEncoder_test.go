@@ -0,0 +1,132 @@
+package logger
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSyslogEncoderRFC5424(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	enc := NewSyslogEncoder(&SyslogEncoderConfig{Hostname: "api-1", AppName: "billing", Facility: 16})
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.FixedZone("", 2*60*60))
+	tests := []struct {
+		entry Entry
+		want  string
+	}{
+		{
+			Entry{Time: at, Date: []byte("2023/10/01 12:00:00"), Level: LevelInfo, Message: []byte("order created")},
+			"<134>1 2023-10-01T12:00:00.123+02:00 api-1 billing " + pid + " - - order created",
+		},
+		{
+			Entry{Level: LevelError, Message: []byte("failed"), Fields: enc.AppendInt(nil, "id", 42)},
+			"<131>1 - api-1 billing " + pid + ` - [fields@32473 id="42"] failed`,
+		},
+		{
+			Entry{
+				Level:   LevelWarning,
+				Caller:  "server/handler.go:42",
+				Prefix:  []byte("[db] "),
+				Message: []byte("slow\nquery"),
+				Context: enc.AppendString(nil, "service", "billing"),
+			},
+			"<132>1 - api-1 billing " + pid + ` - [fields@32473 caller="server/handler.go:42" service="billing"]` +
+				" [db] slow#012query",
+		},
+		{Entry{Level: LevelSuccess}, "<133>1 - api-1 billing " + pid + " - -"},
+	}
+	for _, test := range tests {
+		if got := string(enc.AppendEntry(nil, test.entry)); got != test.want+"\n" {
+			t.Errorf("AppendEntry() = %q, want %q", got, test.want+"\n")
+		}
+	}
+}
+
+func TestSyslogEncoderRFC3164(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+	enc := NewSyslogEncoder(&SyslogEncoderConfig{Format: SyslogRFC3164, Hostname: "api 1", AppName: "billing"})
+	at := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		entry Entry
+		want  string
+	}{
+		{
+			Entry{Time: at, Date: []byte("2023/10/01 12:00:00"), Level: LevelInfo, Message: []byte("order created"),
+				Fields: enc.AppendInt(nil, "id", 42)},
+			"<14>Oct  1 12:00:00 api_1 billing[" + pid + "]: order created id=42",
+		},
+		{
+			Entry{Level: LevelDebug, Caller: "main.go:7", Message: []byte("a\r\nb"),
+				Fields: enc.AppendString(nil, "user", "bob smith")},
+			"<15>api_1 billing[" + pid + `]: a#015#012b caller=main.go:7 user="bob smith"`,
+		},
+		{Entry{Level: LevelFatal}, "<10>api_1 billing[" + pid + "]:"},
+	}
+	for _, test := range tests {
+		if got := string(enc.AppendEntry(nil, test.entry)); got != test.want+"\n" {
+			t.Errorf("AppendEntry() = %q, want %q", got, test.want+"\n")
+		}
+	}
+}
+
+func TestSyslogPriority(t *testing.T) {
+	tests := []struct {
+		facility int
+		level    Level
+		want     string
+	}{
+		{1, LevelFatal, "<10>"},
+		{1, LevelError, "<11>"},
+		{1, LevelWarning, "<12>"},
+		{1, LevelSuccess, "<13>"},
+		{1, LevelInfo, "<14>"},
+		{1, levelRecord, "<14>"},
+		{1, LevelDebug, "<15>"},
+		{1, LevelTrace, "<15>"},
+		{23, LevelError, "<187>"},
+		{16, LevelInfo, "<134>"},
+		// the kernel facility and invalid facilities fall back to user-level messages
+		{0, LevelInfo, "<14>"},
+		{-3, LevelInfo, "<14>"},
+		{24, LevelInfo, "<14>"},
+	}
+	for _, test := range tests {
+		for _, format := range []SyslogFormat{SyslogRFC5424, SyslogRFC3164} {
+			enc := NewSyslogEncoder(&SyslogEncoderConfig{Format: format, Facility: test.facility, Hostname: "h"})
+			got := string(enc.AppendEntry(nil, Entry{Level: test.level}))
+			if got[:len(test.want)] != test.want {
+				t.Errorf("the PRI of %v with the facility %d in the format %d = %q, want %q",
+					test.level, test.facility, format, got, test.want)
+			}
+		}
+	}
+}
+
+func TestSyslogStructuredData(t *testing.T) {
+	enc := NewSyslogEncoder(&SyslogEncoderConfig{Hostname: "h", AppName: "billing", StructuredDataID: "my fields=1"})
+	tests := []struct {
+		fields []byte
+		want   string
+	}{
+		{enc.AppendString(nil, "query", `say "hi" \ [ok]`), ` query="say \"hi\" \\ [ok\]"`},
+		{enc.AppendString(nil, "line", "a\nb"), ` line="a#012b"`},
+		{enc.AppendString(nil, `bad key="]`, "v"), ` bad_key___="v"`},
+		{enc.AppendString(nil, "k123456789012345678901234567890123", "v"), ` k1234567890123456789012345678901="v"`},
+		{enc.AppendError(nil, "err", errors.New(`no "such" file]`)), ` err="no \"such\" file\]"`},
+		{enc.AppendBool(nil, "ok", true), ` ok="true"`},
+		{enc.AppendDuration(nil, "took", 1500*time.Microsecond), ` took="1.5ms"`},
+	}
+	for _, test := range tests {
+		if string(test.fields) != test.want {
+			t.Errorf("got %q, want %q", test.fields, test.want)
+		}
+	}
+
+	got := string(enc.AppendEntry(nil, Entry{Level: LevelInfo, Fields: enc.AppendInt(nil, "id", 1)}))
+	want := "<14>1 - h billing " + strconv.Itoa(os.Getpid()) + ` - [my_fields_1 id="1"]` + "\n"
+	if got != want {
+		t.Errorf("AppendEntry() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package logger
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+)
+
+// syslogLocalAddresses are the addresses of the local syslog daemon on Linux, macOS and BSD.
+var syslogLocalAddresses = [...]string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogWriterConfig is the configuration of a SyslogWriter.
+type SyslogWriterConfig struct {
+	// Network is "unixgram", "unix", "udp" or "tcp". If it's empty, the writer connects to the local syslog daemon,
+	// like /dev/log, and Address is ignored.
+	Network string
+	// Address is the address of the syslog daemon, like "/dev/log" or "syslog:514".
+	Address string
+}
+
+// SyslogWriter is an io.Writer that sends the lines written by a SyslogEncoder to a syslog daemon. Every line is
+// a message, so it can receive the flushed buffers of a FastLogger. Over TCP, the messages are framed with octet
+// counting (RFC 6587), over unix stream sockets they end with a newline, and datagram sockets get one message
+// per datagram. A stream connection is redialed once if a write fails.
+//
+// Example:
+//
+//	syslogWriter, err := logger.NewSyslogWriter(&logger.SyslogWriterConfig{})
+//	if err != nil {
+//		panic(err)
+//	}
+//	log := logger.NewStandardLogger(&logger.StandardLoggerConfig{
+//		UnifiedWriter: syslogWriter,
+//		ShowDate:      true,
+//		Encoder:       logger.NewSyslogEncoder(&logger.SyslogEncoderConfig{AppName: "billing"}),
+//	})
+type SyslogWriter struct {
+	network string
+	address string
+
+	mutex sync.Mutex
+	conn  net.Conn
+	// buf is the buffer of a framed message.
+	buf []byte
+}
+
+// NewSyslogWriter creates a new SyslogWriter and connects to the syslog daemon.
+func NewSyslogWriter(cfg *SyslogWriterConfig) (*SyslogWriter, error) {
+	w := &SyslogWriter{
+		network: cfg.Network,
+		address: cfg.Address,
+	}
+	switch w.network {
+	case "":
+		if err := w.dialLocal(); err != nil {
+			return nil, err
+		}
+		return w, nil
+	case "unixgram", "unix", "udp", "tcp":
+		conn, err := net.Dial(w.network, w.address)
+		if err != nil {
+			return nil, err
+		}
+		w.conn = conn
+		return w, nil
+	default:
+		return nil, fmt.Errorf("logger: unsupported syslog network %q", w.network)
+	}
+}
+
+// dialLocal connects to the first local syslog address that accepts a connection.
+func (w *SyslogWriter) dialLocal() error {
+	for _, network := range [...]string{"unixgram", "unix"} {
+		for _, address := range syslogLocalAddresses {
+			conn, err := net.Dial(network, address)
+			if err == nil {
+				w.network, w.address, w.conn = network, address, conn
+				return nil
+			}
+		}
+	}
+	return errors.New("logger: can't connect to the local syslog daemon")
+}
+
+// Write sends every line of p as a message. It returns the first error, after trying to send all lines.
+func (w *SyslogWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	var firstErr error
+	for rest := p; len(rest) > 0; {
+		msg := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			msg, rest = rest[:i], rest[i+1:]
+		} else {
+			rest = nil
+		}
+		if len(msg) == 0 {
+			continue
+		}
+		if err := w.send(msg); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if firstErr != nil {
+		return 0, firstErr
+	}
+	return len(p), nil
+}
+
+// Close closes the connection.
+func (w *SyslogWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.conn.Close()
+}
+
+func (w *SyslogWriter) send(msg []byte) error {
+	switch w.network {
+	case "tcp":
+		w.buf = strconv.AppendInt(w.buf[:0], int64(len(msg)), 10)
+		w.buf = append(w.buf, ' ')
+		w.buf = append(w.buf, msg...)
+	case "unix":
+		w.buf = append(append(w.buf[:0], msg...), '\n')
+	default:
+		_, err := w.conn.Write(msg)
+		return err
+	}
+	if _, err := w.conn.Write(w.buf); err == nil {
+		return nil
+	}
+	w.conn.Close()
+	conn, err := net.Dial(w.network, w.address)
+	if err != nil {
+		return err
+	}
+	w.conn = conn
+	_, err = w.conn.Write(w.buf)
+	return err
+}
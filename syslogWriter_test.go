@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSyslogWriterTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer listener.Close()
+
+	w, err := NewSyslogWriter(&SyslogWriterConfig{Network: "tcp", Address: listener.Addr().String()})
+	if err != nil {
+		t.Fatalf("NewSyslogWriter() error = %v", err)
+	}
+	defer w.Close()
+	conn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("Accept() error = %v", err)
+	}
+	defer conn.Close()
+
+	p := []byte("<14>1 - h app - - first\n\n<11>1 - h app - - second line\n<15>1 - h app - - no newline")
+	if n, err := w.Write(p); n != len(p) || err != nil {
+		t.Fatalf("Write() = %d, %v, want %d, nil", n, err, len(p))
+	}
+	w.Close()
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	got, err := io.ReadAll(bufio.NewReader(conn))
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	want := "23 <14>1 - h app - - first" +
+		"29 <11>1 - h app - - second line" +
+		"28 <15>1 - h app - - no newline"
+	if string(got) != want {
+		t.Errorf("the syslog daemon got %q, want %q", got, want)
+	}
+}
+
+func TestSyslogWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("ListenPacket() error = %v", err)
+	}
+	defer conn.Close()
+
+	w, err := NewSyslogWriter(&SyslogWriterConfig{Network: "udp", Address: conn.LocalAddr().String()})
+	if err != nil {
+		t.Fatalf("NewSyslogWriter() error = %v", err)
+	}
+	defer w.Close()
+	if _, err = w.Write([]byte("<14>first\n<14>second\n")); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	buf := make([]byte, 1024)
+	for _, want := range []string{"<14>first", "<14>second"} {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("ReadFrom() error = %v", err)
+		}
+		if string(buf[:n]) != want {
+			t.Errorf("the datagram = %q, want %q", buf[:n], want)
+		}
+	}
+}
+
+func TestSyslogWriterErrors(t *testing.T) {
+	saved := syslogLocalAddresses
+	defer func() { syslogLocalAddresses = saved }()
+	dir := t.TempDir()
+	for i := range syslogLocalAddresses {
+		syslogLocalAddresses[i] = filepath.Join(dir, "missing.sock")
+	}
+
+	tests := []*SyslogWriterConfig{
+		{},
+		{Network: "ip", Address: "127.0.0.1"},
+		{Network: "unixgram", Address: filepath.Join(dir, "missing.sock")},
+	}
+	for _, cfg := range tests {
+		w, err := NewSyslogWriter(cfg)
+		if w != nil || err == nil {
+			t.Errorf("NewSyslogWriter(%+v) = %v, %v, want nil and an error", cfg, w, err)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package logger
+
+import "strings"
+
+type templateStepKind uint8
+
+const (
+	templateText templateStepKind = iota
+	templateTime
+	templateLevel
+	templateCaller
+	templatePrefix
+	templateMessage
+	templateFields
+)
+
+var templatePlaceholders = map[string]templateStepKind{
+	"time":   templateTime,
+	"level":  templateLevel,
+	"caller": templateCaller,
+	"prefix": templatePrefix,
+	"msg":    templateMessage,
+	"fields": templateFields,
+}
+
+// templateStep is a step of a compiled template. It appends the text or the part of the entry of its kind.
+type templateStep struct {
+	kind templateStepKind
+	text string
+}
+
+// TemplateEncoder encodes entries into text lines with a layout set by a template, like
+//
+//	{time} [{level}] {caller} {prefix}{msg}
+//
+// The placeholders are:
+//   - {time} is the date of the log;
+//   - {level} is the label of the level, or the name of the level if the logger has no labels;
+//   - {caller} is the location of the code that logged, like "server/handler.go:42";
+//   - {prefix} is the prefix of a record;
+//   - {msg} is the message;
+//   - {fields} is the context fields and the fields, like TextEncoder writes them.
+//
+// Other text, including unknown placeholders, is written as is. If the template has no {fields}, the fields are written
+// at the end of the line. A space after a placeholder that is empty in the log, like {time} of a record without
+// the date, is dropped, as is a space before such a placeholder at the end of the template. Fields are encoded like by
+// TextEncoder.
+type TemplateEncoder struct {
+	TextEncoder
+
+	steps     []templateStep
+	hasFields bool
+	hasCaller bool
+}
+
+// NewTemplateEncoder creates a new TemplateEncoder with the template compiled into steps.
+func NewTemplateEncoder(template string) *TemplateEncoder {
+	enc := &TemplateEncoder{}
+	for len(template) > 0 {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			enc.appendText(template)
+			break
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			enc.appendText(template)
+			break
+		}
+		end += start
+		kind, ok := templatePlaceholders[template[start+1:end]]
+		if !ok {
+			enc.appendText(template[:end+1])
+			template = template[end+1:]
+			continue
+		}
+		enc.appendText(template[:start])
+		enc.steps = append(enc.steps, templateStep{kind: kind})
+		enc.hasFields = enc.hasFields || kind == templateFields
+		enc.hasCaller = enc.hasCaller || kind == templateCaller
+		template = template[end+1:]
+	}
+	return enc
+}
+
+func (enc *TemplateEncoder) appendText(text string) {
+	if len(text) == 0 {
+		return
+	}
+	if last := len(enc.steps) - 1; last >= 0 && enc.steps[last].kind == templateText {
+		enc.steps[last].text += text
+		return
+	}
+	enc.steps = append(enc.steps, templateStep{kind: templateText, text: text})
+}
+
+// isCallerNeeded reports whether the template has {caller}, so the logger finds the callers of the logs.
+func (enc *TemplateEncoder) isCallerNeeded() bool {
+	return enc.hasCaller
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *TemplateEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	isEmpty := false
+	for _, step := range enc.steps {
+		start := len(dst)
+		switch step.kind {
+		case templateText:
+			text := step.text
+			if isEmpty && text[0] == ' ' {
+				text = text[1:]
+			}
+			dst = append(dst, text...)
+		case templateTime:
+			dst = append(dst, entry.Date...)
+		case templateLevel:
+			if entry.Label != nil {
+				dst = append(dst, entry.Label...)
+			} else {
+				dst = append(dst, entry.Level.String()...)
+			}
+		case templateCaller:
+			dst = append(dst, entry.Caller...)
+		case templatePrefix:
+			dst = append(dst, entry.Prefix...)
+		case templateMessage:
+			dst = append(dst, entry.Message...)
+		case templateFields:
+			dst = appendTextFields(dst, entry.Context, entry.Fields)
+		}
+		isEmpty = step.kind != templateText && len(dst) == start
+	}
+	if isEmpty && len(dst) > 0 && dst[len(dst)-1] == ' ' {
+		// the line ends with an empty placeholder
+		dst = dst[:len(dst)-1]
+	}
+	if !enc.hasFields {
+		dst = append(dst, entry.Context...)
+		dst = append(dst, entry.Fields...)
+	}
+	return append(dst, '\n')
+}
+
+// appendTextFields appends the context fields and the fields, encoded by a TextEncoder, without the leading space.
+func appendTextFields(dst, context, fields []byte) []byte {
+	if len(context) > 0 {
+		dst = append(dst, context[1:]...)
+		return append(dst, fields...)
+	}
+	if len(fields) > 0 {
+		dst = append(dst, fields[1:]...)
+	}
+	return dst
+}
@@ -0,0 +1,59 @@
+package logger_test
+
+import (
+	"github.com/Eugene-Usachev/logger"
+	"regexp"
+	"testing"
+)
+
+func TestTemplateEncoder(t *testing.T) {
+	text := logger.NewTextEncoder()
+	entry := logger.Entry{
+		Date:    []byte("2023/10/01 12:00:00"),
+		Level:   logger.LevelInfo,
+		Caller:  "server/handler.go:42",
+		Message: []byte("order created"),
+		Context: text.AppendString(nil, "service", "billing"),
+		Fields:  text.AppendString(nil, "user", "bob smith"),
+	}
+	tests := []struct {
+		template string
+		entry    logger.Entry
+		want     string
+	}{
+		{
+			"{time} [{level}] {caller} {prefix}{msg}", entry,
+			`2023/10/01 12:00:00 [info] server/handler.go:42 order created service=billing user="bob smith"`,
+		},
+		{"{msg} | {fields} | {level}", entry, `order created | service=billing user="bob smith" | info`},
+		{"{level}: {msg}", logger.Entry{Level: logger.LevelError, Label: []byte("ERR"), Message: []byte("failed")},
+			"ERR: failed"},
+		{"{time} {msg}", logger.Entry{Level: logger.LevelInfo, Message: []byte("no date")}, "no date"},
+		{"{time} {caller} {prefix}{msg}", logger.Entry{Level: -1, Prefix: []byte("[db] "), Message: []byte("ping")},
+			"[db] ping"},
+		{"{unknown} {msg} {", logger.Entry{Level: logger.LevelInfo, Message: []byte("kept")}, "{unknown} kept {"},
+		{"{msg}{fields}", logger.Entry{Level: logger.LevelInfo, Message: []byte("no fields")}, "no fields"},
+		{"[{msg}] {fields}", logger.Entry{Level: logger.LevelInfo, Fields: text.AppendInt(nil, "id", 7)}, "[] id=7"},
+	}
+	for _, test := range tests {
+		got := string(logger.NewTemplateEncoder(test.template).AppendEntry(nil, test.entry))
+		if got != test.want+"\n" {
+			t.Errorf("NewTemplateEncoder(%q).AppendEntry() = %q, want %q", test.template, got, test.want+"\n")
+		}
+	}
+}
+
+func TestTemplateEncoderCaller(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	cfg.Encoder = logger.NewTemplateEncoder("{caller} {msg}")
+	log := logger.NewStandardLogger(&cfg)
+	log.Info("info")
+	log.InfoKV("kv", "id", 1)
+	log.FormatInfo("format\n")
+
+	want := regexp.MustCompile(`^([^/ ]+/templateEncoder_test\.go:\d+ (info|kv id=1|format)\n){3}$`)
+	if !want.MatchString(w.info.String()) {
+		t.Errorf("the info writer got %q, want the caller in the test file before each log", w.info.String())
+	}
+}
@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TextEncoder encodes entries into the plain text lines of the logger:
+//
+//	2023/10/01 12:00:00 INFO service=billing [prefix] message key=value key="quoted value"
+//
+// The date is followed by the label of the level if the logger has labels, the caller if the logger shows callers,
+// the context fields, the prefix of a record, the message and the fields. Values are quoted if they contain spaces,
+// quotes, '=' or control characters.
+type TextEncoder struct{}
+
+// NewTextEncoder creates a new TextEncoder. It is the default encoder of the loggers.
+func NewTextEncoder() *TextEncoder {
+	return &TextEncoder{}
+}
+
+// AppendEntry appends the encoded entry with a trailing newline to dst.
+func (enc *TextEncoder) AppendEntry(dst []byte, entry Entry) []byte {
+	if entry.Date != nil {
+		dst = append(dst, entry.Date...)
+		dst = append(dst, ' ')
+	}
+	if len(entry.Label) > 0 {
+		dst = append(dst, entry.Label...)
+		dst = append(dst, ' ')
+	}
+	if len(entry.Caller) > 0 {
+		dst = append(dst, entry.Caller...)
+		dst = append(dst, ' ')
+	}
+	if len(entry.Context) > 0 {
+		// skip the space before the first field
+		dst = append(dst, entry.Context[1:]...)
+		dst = append(dst, ' ')
+	}
+	dst = append(dst, entry.Prefix...)
+	dst = append(dst, entry.Message...)
+	if len(entry.Fields) > 0 {
+		if len(entry.Prefix) == 0 && len(entry.Message) == 0 {
+			dst = append(dst, entry.Fields[1:]...)
+		} else {
+			dst = append(dst, entry.Fields...)
+		}
+	}
+	return append(dst, '\n')
+}
+
+func (enc *TextEncoder) appendKey(dst []byte, key string) []byte {
+	return appendTextKey(dst, key)
+}
+
+// appendTextKey appends a space and the key followed by '='. Spaces, quotes, '=' and control characters in the key
+// are replaced with '_'.
+func appendTextKey(dst []byte, key string) []byte {
+	dst = append(dst, ' ')
+	for i := 0; i < len(key); i++ {
+		if key[i] <= ' ' || key[i] == '=' || key[i] == '"' || key[i] == 0x7f {
+			dst = append(dst, '_')
+		} else {
+			dst = append(dst, key[i])
+		}
+	}
+	return append(dst, '=')
+}
+
+func (enc *TextEncoder) AppendString(dst []byte, key, value string) []byte {
+	return appendString(enc.appendKey(dst, key), value)
+}
+
+func (enc *TextEncoder) AppendInt(dst []byte, key string, value int64) []byte {
+	return strconv.AppendInt(enc.appendKey(dst, key), value, 10)
+}
+
+func (enc *TextEncoder) AppendUint(dst []byte, key string, value uint64) []byte {
+	return strconv.AppendUint(enc.appendKey(dst, key), value, 10)
+}
+
+func (enc *TextEncoder) AppendFloat(dst []byte, key string, value float64) []byte {
+	return strconv.AppendFloat(enc.appendKey(dst, key), value, 'f', -1, 64)
+}
+
+func (enc *TextEncoder) AppendBool(dst []byte, key string, value bool) []byte {
+	return strconv.AppendBool(enc.appendKey(dst, key), value)
+}
+
+// AppendDuration appends the duration in milliseconds, like "took=1.5ms".
+func (enc *TextEncoder) AppendDuration(dst []byte, key string, value time.Duration) []byte {
+	return appendDuration(enc.appendKey(dst, key), value)
+}
+
+// AppendTime appends the time in RFC 3339 format.
+func (enc *TextEncoder) AppendTime(dst []byte, key string, value time.Time) []byte {
+	return appendTime(enc.appendKey(dst, key), value)
+}
+
+func (enc *TextEncoder) AppendError(dst []byte, key string, err error) []byte {
+	return appendString(enc.appendKey(dst, key), err.Error())
+}
+
+func (enc *TextEncoder) AppendAny(dst []byte, key string, value interface{}) []byte {
+	if value == nil {
+		return append(enc.appendKey(dst, key), "<nil>"...)
+	}
+	return appendString(enc.appendKey(dst, key), fmt.Sprint(value))
+}
@@ -0,0 +1,138 @@
+package logger
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// The formats of the dates of the logs. Any other format is used as a layout of time.Format.
+const (
+	// TimeFormatDefault is "2023/10/01 12:00:00".
+	TimeFormatDefault = "2006/01/02 15:04:05"
+	// TimeFormatRFC3339 is "2023-10-01T12:00:00+02:00".
+	TimeFormatRFC3339 = time.RFC3339
+	// TimeFormatRFC3339Milli is "2023-10-01T12:00:00.000+02:00".
+	TimeFormatRFC3339Milli = "2006-01-02T15:04:05.000Z07:00"
+	// TimeFormatRFC3339Micro is "2023-10-01T12:00:00.000000+02:00".
+	TimeFormatRFC3339Micro = "2006-01-02T15:04:05.000000Z07:00"
+	// TimeFormatRFC3339Nano is "2023-10-01T12:00:00.000000000+02:00". Unlike time.RFC3339Nano, it keeps
+	// the trailing zeros, so all dates have the same width.
+	TimeFormatRFC3339Nano = "2006-01-02T15:04:05.000000000Z07:00"
+	// TimeFormatUnix is the number of seconds since the Unix epoch, like "1696154400".
+	TimeFormatUnix = "unix"
+	// TimeFormatUnixMilli is the number of milliseconds since the Unix epoch, like "1696154400000".
+	TimeFormatUnixMilli = "unixmilli"
+)
+
+// appendFormattedTime appends the time in the format, one of the TimeFormat constants or a layout, to dst.
+func appendFormattedTime(dst []byte, t time.Time, format string) []byte {
+	switch format {
+	case TimeFormatUnix:
+		return strconv.AppendInt(dst, t.Unix(), 10)
+	case TimeFormatUnixMilli:
+		return strconv.AppendInt(dst, t.UnixMilli(), 10)
+	default:
+		return t.AppendFormat(dst, format)
+	}
+}
+
+// dateLayout is a format split around its fractional seconds, like ".000", so the date is formatted once per second
+// and only the fractional seconds are formatted for every log.
+type dateLayout struct {
+	// prefix and suffix are the formats before and after the fractional seconds. The prefix is the whole format if
+	// it has no fractional seconds.
+	prefix, suffix string
+	// separator is the '.' or ',' before the fractional seconds.
+	separator byte
+	// digits is the number of digits of the fractional seconds, or 0 if the format has none.
+	digits int
+	// isVariable indicates whether the fractional seconds have no fixed width, like ".999", so the whole date is
+	// formatted for every log.
+	isVariable bool
+}
+
+// newDateLayout splits the format, one of the TimeFormat constants or a layout, around its fractional seconds.
+func newDateLayout(format string) dateLayout {
+	if format == TimeFormatUnix || format == TimeFormatUnixMilli {
+		return dateLayout{prefix: format}
+	}
+	for i := 0; i+1 < len(format); i++ {
+		if (format[i] != '.' && format[i] != ',') || (format[i+1] != '0' && format[i+1] != '9') {
+			continue
+		}
+		end := i + 1
+		for end < len(format) && format[end] == format[i+1] {
+			end++
+		}
+		if end < len(format) && format[end] >= '0' && format[end] <= '9' {
+			continue
+		}
+		if format[i+1] == '9' || end-i-1 > 9 {
+			return dateLayout{prefix: format, isVariable: true}
+		}
+		return dateLayout{prefix: format[:i], suffix: format[end:], separator: format[i], digits: end - i - 1}
+	}
+	return dateLayout{prefix: format}
+}
+
+// newPreciseDateLayout is newDateLayout for precise dates. If the format has seconds, like "05", but no fractional
+// seconds, microseconds are written after the seconds, so the logs of the same second keep their order.
+func newPreciseDateLayout(format string) dateLayout {
+	layout := newDateLayout(format)
+	if layout.digits > 0 || layout.isVariable || format == TimeFormatUnix || format == TimeFormatUnixMilli {
+		return layout
+	}
+	i := strings.LastIndex(format, "05")
+	if i < 0 {
+		return layout
+	}
+	return dateLayout{prefix: format[:i+2], suffix: format[i+2:], separator: '.', digits: 6}
+}
+
+// secondDate is the formatted date of a second.
+type secondDate struct {
+	second int64
+	// prefix and suffix are the parts of the date before and after the fractional seconds.
+	prefix, suffix []byte
+}
+
+// appendPreciseDate appends the time in the timeFormat to dst. The parts of the date other than the fractional seconds
+// are formatted once per second.
+func (logger *StandardLogger) appendPreciseDate(dst []byte, t time.Time) []byte {
+	layout := &logger.preciseLayout
+	if layout.isVariable {
+		return t.AppendFormat(dst, layout.prefix)
+	}
+	if layout.prefix == TimeFormatUnixMilli {
+		return strconv.AppendInt(dst, t.UnixMilli(), 10)
+	}
+	date := logger.seconds.Load()
+	if date == nil || date.second != t.Unix() {
+		date = &secondDate{
+			second: t.Unix(),
+			prefix: appendFormattedTime(nil, t, layout.prefix),
+		}
+		if layout.suffix != "" {
+			date.suffix = t.AppendFormat(nil, layout.suffix)
+		}
+		logger.seconds.Store(date)
+	}
+	dst = append(dst, date.prefix...)
+	if layout.digits > 0 {
+		dst = append(dst, layout.separator)
+		fraction := t.Nanosecond()
+		for i := layout.digits; i < 9; i++ {
+			fraction /= 10
+		}
+		start := len(dst)
+		for i := 0; i < layout.digits; i++ {
+			dst = append(dst, '0')
+		}
+		for i := len(dst) - 1; i >= start; i-- {
+			dst[i] = byte('0' + fraction%10)
+			fraction /= 10
+		}
+	}
+	return append(dst, date.suffix...)
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAppendFormattedTime(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.FixedZone("", 2*60*60))
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{TimeFormatDefault, "2023/10/01 12:00:00"},
+		{TimeFormatRFC3339, "2023-10-01T12:00:00+02:00"},
+		{TimeFormatRFC3339Milli, "2023-10-01T12:00:00.123+02:00"},
+		{TimeFormatRFC3339Micro, "2023-10-01T12:00:00.123456+02:00"},
+		{TimeFormatRFC3339Nano, "2023-10-01T12:00:00.123456789+02:00"},
+		{TimeFormatUnix, "1696154400"},
+		{TimeFormatUnixMilli, "1696154400123"},
+		{"15:04:05.000", "12:00:00.123"},
+	}
+	for _, test := range tests {
+		if got := string(appendFormattedTime(nil, at, test.format)); got != test.want {
+			t.Errorf("the time in %q = %q, want %q", test.format, got, test.want)
+		}
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.UTC)
+	tests := []struct {
+		format  string
+		encoder Encoder
+		want    string
+	}{
+		{"", NewTextEncoder(), "2023/10/01 12:00:00 order created\n"},
+		{TimeFormatRFC3339Milli, NewTextEncoder(), "2023-10-01T12:00:00.123Z order created\n"},
+		{TimeFormatUnixMilli, NewJSONEncoder(), `{"time":"1696161600123","level":"info","msg":"order created"}` + "\n"},
+		{TimeFormatRFC3339, NewJSONEncoder(), `{"time":"2023-10-01T12:00:00Z","level":"info","msg":"order created"}` + "\n"},
+		{"15:04:05", NewLogfmtEncoder(), "ts=12:00:00 level=info msg=\"order created\"\n"},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		logger := NewStandardLogger(&StandardLoggerConfig{
+			InfoWriter: &buf,
+			ShowDate:   true,
+			TimeFormat: test.format,
+			Encoder:    test.encoder,
+		})
+		logger.logEntryAt(LevelInfo, at, []byte("order created"), nil)
+		if buf.String() != test.want {
+			t.Errorf("the log with the time format %q and %T = %q, want %q", test.format, test.encoder, buf.String(),
+				test.want)
+		}
+	}
+}
+
+func TestPreciseDate(t *testing.T) {
+	at := time.Date(2023, 10, 1, 12, 0, 0, 123456789, time.FixedZone("", 2*60*60))
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{TimeFormatDefault, "2023/10/01 12:00:00.123456"},
+		{TimeFormatRFC3339, "2023-10-01T12:00:00.123456+02:00"},
+		{TimeFormatRFC3339Milli, "2023-10-01T12:00:00.123+02:00"},
+		{TimeFormatRFC3339Nano, "2023-10-01T12:00:00.123456789+02:00"},
+		{"15:04:05,000", "12:00:00,123"},
+		{"15:04:05.999", "12:00:00.123"},
+		{"15:04", "12:00"},
+		{TimeFormatUnix, "1696154400"},
+		{TimeFormatUnixMilli, "1696154400123"},
+	}
+	for _, test := range tests {
+		logger := &StandardLogger{
+			preciseLayout: newPreciseDateLayout(test.format),
+			seconds:       &atomic.Pointer[secondDate]{},
+		}
+		if got := string(logger.appendPreciseDate(nil, at)); got != test.want {
+			t.Errorf("the precise date in %q = %q, want %q", test.format, got, test.want)
+		}
+	}
+}
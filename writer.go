@@ -0,0 +1,96 @@
+package logger
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+)
+
+// levelWriter is an io.Writer that splits the written bytes on newlines and logs every line with the level.
+// The last line is kept until its newline is written.
+type levelWriter struct {
+	logger entryLogger
+	level  Level
+
+	mutex   sync.Mutex
+	partial []byte
+}
+
+// Write logs every complete line of p. It never returns an error.
+func (w *levelWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	w.mutex.Lock()
+	for len(p) > 0 {
+		i := bytes.IndexByte(p, '\n')
+		if i < 0 {
+			w.partial = append(w.partial, p...)
+			break
+		}
+		line := p[:i]
+		if len(w.partial) > 0 {
+			w.partial = append(w.partial, line...)
+			line = w.partial
+		}
+		if len(line) > 0 && line[len(line)-1] == '\r' {
+			line = line[:len(line)-1]
+		}
+		if w.logger.isEnabled(w.level) {
+			w.logger.logEntry(w.level, line, nil)
+		}
+		w.partial = w.partial[:0]
+		p = p[i+1:]
+	}
+	w.mutex.Unlock()
+	return n, nil
+}
+
+// Writer returns an io.Writer that logs every written line to the writer of the level with the date and the context
+// of the logger. Use it for libraries that write to an io.Writer or a *log.Logger. A writer of LevelFatal exits the
+// program on the first line. Levels out of LevelTrace..LevelFatal are replaced by LevelInfo.
+func (logger *StandardLogger) Writer(level Level) io.Writer {
+	return &levelWriter{logger: logger, level: writerLevel(level)}
+}
+
+// Writer returns an io.Writer that logs every written line to the buffer of the level with the date and the context
+// of the logger. Use it for libraries that write to an io.Writer or a *log.Logger. A writer of LevelFatal exits the
+// program on the first line. Levels out of LevelTrace..LevelFatal are replaced by LevelInfo.
+//
+// Example:
+//
+//	server := &http.Server{ErrorLog: log.New(fastLogger.Writer(logger.LevelWarning), "", 0)}
+func (logger *FastLogger) Writer(level Level) io.Writer {
+	return &levelWriter{logger: logger, level: writerLevel(level)}
+}
+
+// writerLevel returns the level, or LevelInfo if the level is not one of the levels from LevelTrace to LevelFatal.
+func writerLevel(level Level) Level {
+	if level < LevelTrace || level > LevelFatal {
+		return LevelInfo
+	}
+	return level
+}
+
+// NewStdLog returns a *log.Logger that logs every line to the logger with the level.
+func NewStdLog(logger Logger, level Level) *log.Logger {
+	return log.New(logger.Writer(level), "", 0)
+}
+
+// RedirectStdLog redirects the output of the global log package to the logger with the level. The flags of the log
+// package are cleared, because the logger writes its own date. It returns a function that restores the output and
+// the flags.
+//
+// Example:
+//
+//	restore := logger.RedirectStdLog(fastLogger, logger.LevelInfo)
+//	defer restore()
+func RedirectStdLog(logger Logger, level Level) func() {
+	flags := log.Flags()
+	output := log.Writer()
+	log.SetFlags(0)
+	log.SetOutput(logger.Writer(level))
+	return func() {
+		log.SetFlags(flags)
+		log.SetOutput(output)
+	}
+}
@@ -0,0 +1,67 @@
+package logger_test
+
+import (
+	"bytes"
+	"github.com/Eugene-Usachev/logger"
+	"log"
+	"testing"
+	"time"
+)
+
+func TestWriterLines(t *testing.T) {
+	tests := []struct {
+		writes []string
+		want   string
+	}{
+		{[]string{"one\n"}, "one\n"},
+		{[]string{"one\ntwo\n"}, "one\ntwo\n"},
+		{[]string{"on", "e\ntw", "o\n"}, "one\ntwo\n"},
+		{[]string{"crlf\r\n"}, "crlf\n"},
+		{[]string{"\n"}, "\n"},
+		{[]string{"partial"}, ""},
+	}
+	for _, test := range tests {
+		var buf bytes.Buffer
+		w := logger.NewStandardLogger(&logger.StandardLoggerConfig{WarningWriter: &buf}).Writer(logger.LevelWarning)
+		for _, p := range test.writes {
+			if n, err := w.Write([]byte(p)); n != len(p) || err != nil {
+				t.Errorf("Write(%q) = %d, %v, want %d, nil", p, n, err, len(p))
+			}
+		}
+		if buf.String() != test.want {
+			t.Errorf("the writes %q logged %q, want %q", test.writes, buf.String(), test.want)
+		}
+	}
+}
+
+func TestWriterLevels(t *testing.T) {
+	var w levelWriters
+	cfg := w.config()
+	cfg.Level = logger.LevelInfo
+	fastLogger := logger.NewFastLogger(&logger.FastLoggerConfig{StandardLoggerConfig: cfg, FlushInterval: time.Hour})
+	defer fastLogger.Stop()
+	fastLogger.Writer(logger.LevelDebug).Write([]byte("dropped\n"))
+	fastLogger.Writer(logger.Level(42)).Write([]byte("invalid level\n"))
+	fastLogger.Writer(logger.Level(-1)).Write([]byte("negative level\n"))
+	logger.NewStdLog(fastLogger, logger.LevelError).Print("std log")
+	fastLogger.Flush()
+	checkLevelWriters(t, &w, map[string]string{
+		"debug": "",
+		"info":  "invalid level\nnegative level\n",
+		"error": "std log\n",
+	})
+}
+
+func TestRedirectStdLog(t *testing.T) {
+	var buf bytes.Buffer
+	flags, output := log.Flags(), log.Writer()
+	restore := logger.RedirectStdLog(logger.NewStandardLogger(&logger.StandardLoggerConfig{InfoWriter: &buf}), logger.LevelInfo)
+	log.Print("redirected")
+	restore()
+	if buf.String() != "redirected\n" {
+		t.Errorf("the global log package logged %q, want %q", buf.String(), "redirected\n")
+	}
+	if log.Flags() != flags || log.Writer() != output {
+		t.Errorf("restore() did not restore the flags and the output of the global log package")
+	}
+}